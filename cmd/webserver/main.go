@@ -1,8 +1,14 @@
 package main
 
-import "github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp"
+import (
+	"log"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp"
+)
 
 func main() {
 	app := webapp.New()
-	app.Start()
+	if err := app.Start(); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,12 @@
+//go:generate mockery --dir=. --output=../../infrastructure/service/mock --name=CityService --structname=MockCityService --outpkg=mock --filename=city_service.go --disable-version-string
+package gateway
+
+import (
+	"context"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+type CityService interface {
+	GetCityByIBGECode(ctx context.Context, ibgeCode model.IBGECode) (*model.City, *model.CustomError)
+}
@@ -8,5 +8,5 @@ import (
 )
 
 type ViaCepService interface {
-	GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*string, *model.CustomError)
+	GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError)
 }
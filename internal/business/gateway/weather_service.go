@@ -3,10 +3,15 @@ package gateway
 
 import (
 	"context"
+	"time"
 
 	"github.com/Berchon/weather-cloud-run/internal/business/model"
 )
 
 type WeatherService interface {
-	GetWeatherByCity(ctx context.Context, city string) (*float64, *model.CustomError)
+	GetWeatherByCity(ctx context.Context, city model.City) (*model.Weather, *model.CustomError)
+	GetConditionsByCity(ctx context.Context, city model.City) (*model.WeatherConditions, *model.CustomError)
+	GetForecastByCity(ctx context.Context, city model.City, days int) (*model.WeatherForecast, *model.CustomError)
+	GetHistoryByCity(ctx context.Context, city model.City, date time.Time) (*model.WeatherForecast, *model.CustomError)
+	GetAirQualityByCity(ctx context.Context, city model.City) (*model.AirQualityReport, *model.CustomError)
 }
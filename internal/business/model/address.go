@@ -0,0 +1,20 @@
+package model
+
+type Address struct {
+	ZipCode      string       `json:"zip_code"`
+	Street       string       `json:"street,omitempty"`
+	Neighborhood string       `json:"neighborhood,omitempty"`
+	City         string       `json:"city"`
+	State        string       `json:"state"`
+	IBGECode     string       `json:"ibge_code,omitempty"`
+	Coordinates  *Coordinates `json:"coordinates,omitempty"`
+}
+
+func (a *Address) ToCity() City {
+	return City{
+		Name:        a.City,
+		State:       a.State,
+		IBGECode:    a.IBGECode,
+		Coordinates: a.Coordinates,
+	}
+}
@@ -0,0 +1,28 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressToCity(t *testing.T) {
+	t.Run("Should carry city, state, IBGE code and coordinates", func(t *testing.T) {
+		coordinates := &model.Coordinates{Latitude: -9.07, Longitude: -44.36}
+		address := model.Address{
+			ZipCode:     "64900-000",
+			City:        "Bom Jesus",
+			State:       "PI",
+			IBGECode:    "2201903",
+			Coordinates: coordinates,
+		}
+
+		assert.Equal(t, model.City{
+			Name:        "Bom Jesus",
+			State:       "PI",
+			IBGECode:    "2201903",
+			Coordinates: coordinates,
+		}, address.ToCity())
+	})
+}
@@ -0,0 +1,63 @@
+package model
+
+// AirQuality holds the pollutant concentrations in μg/m3 and the two indexes
+// WeatherAPI computes: US EPA (1 to 6) and UK DEFRA (1 to 10).
+type AirQuality struct {
+	CO            float64 `json:"co"`
+	NO2           float64 `json:"no2"`
+	O3            float64 `json:"o3"`
+	SO2           float64 `json:"so2"`
+	PM2_5         float64 `json:"pm2_5"`
+	PM10          float64 `json:"pm10"`
+	USEPAIndex    int     `json:"us_epa_index"`
+	USEPACategory string  `json:"us_epa_category,omitempty"`
+	GBDefraIndex  int     `json:"gb_defra_index"`
+	GBDefraBand   string  `json:"gb_defra_band,omitempty"`
+}
+
+type WeatherAlert struct {
+	Headline    string `json:"headline"`
+	Event       string `json:"event"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Urgency     string `json:"urgency"`
+	Areas       string `json:"areas"`
+	Effective   string `json:"effective"`
+	Expires     string `json:"expires"`
+	Description string `json:"description"`
+	Instruction string `json:"instruction"`
+}
+
+type AirQualityReport struct {
+	Location   Location       `json:"location"`
+	AirQuality AirQuality     `json:"air_quality"`
+	Alerts     []WeatherAlert `json:"alerts"`
+}
+
+var usEPACategories = []string{
+	"Good",
+	"Moderate",
+	"Unhealthy for sensitive groups",
+	"Unhealthy",
+	"Very unhealthy",
+	"Hazardous",
+}
+
+// Classify fills the labels of both indexes; out of range indexes get none.
+func (a AirQuality) Classify() AirQuality {
+	if a.USEPAIndex >= 1 && a.USEPAIndex <= len(usEPACategories) {
+		a.USEPACategory = usEPACategories[a.USEPAIndex-1]
+	}
+
+	switch {
+	case a.GBDefraIndex >= 1 && a.GBDefraIndex <= 3:
+		a.GBDefraBand = "Low"
+	case a.GBDefraIndex >= 4 && a.GBDefraIndex <= 6:
+		a.GBDefraBand = "Moderate"
+	case a.GBDefraIndex >= 7 && a.GBDefraIndex <= 9:
+		a.GBDefraBand = "High"
+	case a.GBDefraIndex == 10:
+		a.GBDefraBand = "Very High"
+	}
+	return a
+}
@@ -0,0 +1,39 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAirQualityClassify(t *testing.T) {
+	t.Run("Should label both indexes When they are in range", func(t *testing.T) {
+		cases := []struct {
+			epa, defra int
+			category   string
+			band       string
+		}{
+			{1, 1, "Good", "Low"},
+			{2, 4, "Moderate", "Moderate"},
+			{3, 6, "Unhealthy for sensitive groups", "Moderate"},
+			{4, 7, "Unhealthy", "High"},
+			{5, 9, "Very unhealthy", "High"},
+			{6, 10, "Hazardous", "Very High"},
+		}
+		for _, c := range cases {
+			got := model.AirQuality{USEPAIndex: c.epa, GBDefraIndex: c.defra}.Classify()
+
+			assert.Equal(t, c.category, got.USEPACategory)
+			assert.Equal(t, c.band, got.GBDefraBand)
+		}
+	})
+
+	t.Run("Should leave labels empty When indexes are missing", func(t *testing.T) {
+		got := model.AirQuality{PM10: 12}.Classify()
+
+		assert.Empty(t, got.USEPACategory)
+		assert.Empty(t, got.GBDefraBand)
+		assert.Equal(t, 12.0, got.PM10)
+	})
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"math"
+	"net/http"
+	"strings"
+)
+
+// brazilianStateCodes are the UFs accepted when a city is queried by name.
+var brazilianStateCodes = map[string]bool{
+	"AC": true, "AL": true, "AP": true, "AM": true, "BA": true, "CE": true, "DF": true,
+	"ES": true, "GO": true, "MA": true, "MT": true, "MS": true, "MG": true, "PA": true,
+	"PB": true, "PR": true, "PE": true, "PI": true, "RJ": true, "RN": true, "RS": true,
+	"RO": true, "RR": true, "SC": true, "SP": true, "SE": true, "TO": true,
+}
+
+type Coordinates struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+}
+
+func BuildCoordinates(latitude, longitude float64) (*Coordinates, *CustomError) {
+	if math.IsNaN(latitude) || math.IsNaN(longitude) ||
+		latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, NewCodedError(ErrorCodeInvalidCoordinates, http.StatusUnprocessableEntity, "invalid coordinates")
+	}
+	return &Coordinates{Latitude: latitude, Longitude: longitude}, nil
+}
+
+// City identifies the place to query the weather for. Name alone is ambiguous
+// in Brazil ("Bom Jesus" exists in five states), so State carries the UF and
+// providers use IBGECode or Coordinates when they know them.
+type City struct {
+	Name        string
+	State       string
+	IBGECode    string
+	Coordinates *Coordinates
+}
+
+// BuildCity requires a city name and one of the 27 UFs, in any case.
+func BuildCity(name, state string) (*City, *CustomError) {
+	name = strings.Join(strings.Fields(name), " ")
+	state = strings.ToUpper(strings.TrimSpace(state))
+	if name == "" || !brazilianStateCodes[state] {
+		return nil, NewCodedError(ErrorCodeInvalidCity, http.StatusUnprocessableEntity, "invalid city")
+	}
+	return &City{Name: name, State: state}, nil
+}
@@ -0,0 +1,52 @@
+package model_test
+
+import (
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCity(t *testing.T) {
+	t.Run("Should return a city When given a name and a known UF", func(t *testing.T) {
+		got, err := model.BuildCity("  Porto   Alegre ", "rs")
+
+		assert.Nil(t, err)
+		assert.Equal(t, &model.City{Name: "Porto Alegre", State: "RS"}, got)
+	})
+
+	t.Run("Should return an error When the UF is unknown", func(t *testing.T) {
+		got, err := model.BuildCity("Porto Alegre", "XX")
+
+		assert.Nil(t, got)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+		assert.Equal(t, model.ErrorCodeInvalidCity, err.Code)
+	})
+
+	t.Run("Should return an error When the name is blank", func(t *testing.T) {
+		got, err := model.BuildCity("  ", "RS")
+
+		assert.Nil(t, got)
+		assert.Equal(t, "invalid city", err.Error())
+	})
+}
+
+func TestBuildCoordinates(t *testing.T) {
+	t.Run("Should return coordinates When they are in range", func(t *testing.T) {
+		got, err := model.BuildCoordinates(-30.03, -51.2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &model.Coordinates{Latitude: -30.03, Longitude: -51.2}, got)
+	})
+
+	t.Run("Should return an error When they are out of range", func(t *testing.T) {
+		for _, c := range [][2]float64{{-91, 0}, {91, 0}, {0, -181}, {0, 181}, {math.NaN(), 0}} {
+			got, err := model.BuildCoordinates(c[0], c[1])
+
+			assert.Nil(t, got)
+			assert.Equal(t, model.ErrorCodeInvalidCoordinates, err.Code)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package model
+
+const (
+	kilometersPerMile   = 1.609344
+	inchesOfMercuryByMb = 0.0295299830714
+	millimetersPerInch  = 25.4
+)
+
+// WeatherConditions is what weather providers return for the current
+// conditions, in metric units only; Conditions is its rendering with the
+// imperial equivalents.
+type WeatherConditions struct {
+	Celsius          float64
+	FeelsLikeCelsius float64
+	Humidity         int
+	WindKph          float64
+	WindDegree       int
+	WindDirection    string
+	PressureMb       float64
+	PrecipitationMm  float64
+	UV               float64
+	ConditionText    string
+	ConditionIcon    string
+	LastUpdated      string
+	Location         Location
+}
+
+type Conditions struct {
+	Temperature
+	FeelsLike     Temperature   `json:"feels_like"`
+	Humidity      int           `json:"humidity"`
+	Wind          Wind          `json:"wind"`
+	Pressure      Pressure      `json:"pressure"`
+	Precipitation Precipitation `json:"precipitation"`
+	UV            float64       `json:"uv"`
+	Condition     Condition     `json:"condition"`
+	LastUpdated   string        `json:"last_updated"`
+	Location      Location      `json:"location"`
+}
+
+type Wind struct {
+	Kph       float64 `json:"kph"`
+	Mph       float64 `json:"mph"`
+	Degree    int     `json:"degree"`
+	Direction string  `json:"direction"`
+}
+
+type Pressure struct {
+	Mb   float64 `json:"mb"`
+	InHg float64 `json:"in_hg"`
+}
+
+type Precipitation struct {
+	Mm float64 `json:"mm"`
+	In float64 `json:"in"`
+}
+
+type Condition struct {
+	Text string `json:"text"`
+	Icon string `json:"icon"`
+}
+
+// NewConditions converts the provider conditions. Temperatures follow
+// options; speeds are rounded to one decimal place and pressure and
+// precipitation to two, which is what the imperial units need to be useful.
+func NewConditions(weatherConditions WeatherConditions, options TemperatureOptions) Conditions {
+	return Conditions{
+		Temperature: NewTemperature(weatherConditions.Celsius, options),
+		FeelsLike:   NewTemperature(weatherConditions.FeelsLikeCelsius, options),
+		Humidity:    weatherConditions.Humidity,
+		Wind: Wind{
+			Kph:       roundToDecimalPlaces(weatherConditions.WindKph, 1),
+			Mph:       roundToDecimalPlaces(convertKphToMph(weatherConditions.WindKph), 1),
+			Degree:    weatherConditions.WindDegree,
+			Direction: weatherConditions.WindDirection,
+		},
+		Pressure: Pressure{
+			Mb:   roundToDecimalPlaces(weatherConditions.PressureMb, 2),
+			InHg: roundToDecimalPlaces(convertMbToInHg(weatherConditions.PressureMb), 2),
+		},
+		Precipitation: Precipitation{
+			Mm: roundToDecimalPlaces(weatherConditions.PrecipitationMm, 2),
+			In: roundToDecimalPlaces(convertMmToIn(weatherConditions.PrecipitationMm), 2),
+		},
+		UV: weatherConditions.UV,
+		Condition: Condition{
+			Text: weatherConditions.ConditionText,
+			Icon: weatherConditions.ConditionIcon,
+		},
+		LastUpdated: weatherConditions.LastUpdated,
+		Location:    weatherConditions.Location,
+	}
+}
+
+func convertKphToMph(kph float64) float64 {
+	return kph / kilometersPerMile
+}
+
+func convertMbToInHg(mb float64) float64 {
+	return mb * inchesOfMercuryByMb
+}
+
+func convertMmToIn(mm float64) float64 {
+	return mm / millimetersPerInch
+}
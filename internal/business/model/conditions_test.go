@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitConversions(t *testing.T) {
+	t.Run("Should convert speed pressure and precipitation When given metric values", func(t *testing.T) {
+		assert.InDelta(t, 62.137, convertKphToMph(100), 0.001)
+		assert.InDelta(t, 29.921, convertMbToInHg(1013.25), 0.001)
+		assert.InDelta(t, 1.0, convertMmToIn(25.4), 0.0001)
+	})
+}
+
+func TestNewConditions(t *testing.T) {
+	t.Run("Should render metric and imperial units When given provider conditions", func(t *testing.T) {
+		weatherConditions := WeatherConditions{
+			Celsius: 20, FeelsLikeCelsius: 18, Humidity: 72,
+			WindKph: 15.1, WindDegree: 140, WindDirection: "SE",
+			PressureMb: 1016, PrecipitationMm: 0.3, UV: 4,
+			ConditionText: "Partly cloudy", ConditionIcon: "//cdn.weatherapi.com/116.png",
+			LastUpdated: "2025-07-01 14:15", Location: Location{Name: "Porto Alegre"},
+		}
+
+		got := NewConditions(weatherConditions, DefaultTemperatureOptions())
+
+		assert.Equal(t, Conditions{
+			Temperature:   Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293},
+			FeelsLike:     Temperature{Celsius: 18, Fahrenheit: 64.4, Kelvin: 291},
+			Humidity:      72,
+			Wind:          Wind{Kph: 15.1, Mph: 9.4, Degree: 140, Direction: "SE"},
+			Pressure:      Pressure{Mb: 1016, InHg: 30},
+			Precipitation: Precipitation{Mm: 0.3, In: 0.01},
+			UV:            4,
+			Condition:     Condition{Text: "Partly cloudy", Icon: "//cdn.weatherapi.com/116.png"},
+			LastUpdated:   "2025-07-01 14:15",
+			Location:      Location{Name: "Porto Alegre"},
+		}, got)
+	})
+}
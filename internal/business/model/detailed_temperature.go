@@ -0,0 +1,22 @@
+package model
+
+// TemperatureIncludes lists the optional blocks a client asked for through
+// ?include=.
+type TemperatureIncludes struct {
+	Location   bool
+	AirQuality bool
+}
+
+func (i TemperatureIncludes) Any() bool {
+	return i.Location || i.AirQuality
+}
+
+// DetailedTemperature is the temperature answer enriched with the blocks
+// asked for: the address the zip code resolved to and the location the
+// weather provider matched, and the air quality report.
+type DetailedTemperature struct {
+	Temperature
+	Address    *Address          `json:"address,omitempty"`
+	Location   *Location         `json:"location,omitempty"`
+	AirQuality *AirQualityReport `json:"air_quality,omitempty"`
+}
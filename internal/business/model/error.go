@@ -1,17 +1,83 @@
 package model
 
+import "net/http"
+
+// ErrorCode is the stable, machine readable identifier of an error. Clients
+// should branch on it rather than on the message, which may change.
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidRequest      ErrorCode = "INVALID_REQUEST"
+	ErrorCodeInvalidZipCode      ErrorCode = "INVALID_ZIPCODE"
+	ErrorCodeInvalidCity         ErrorCode = "INVALID_CITY"
+	ErrorCodeInvalidCoordinates  ErrorCode = "INVALID_COORDINATES"
+	ErrorCodeInvalidIBGECode     ErrorCode = "INVALID_IBGE_CODE"
+	ErrorCodeInvalidDate         ErrorCode = "INVALID_DATE"
+	ErrorCodeZipCodeNotFound     ErrorCode = "ZIPCODE_NOT_FOUND"
+	ErrorCodeLocationNotFound    ErrorCode = "LOCATION_NOT_FOUND"
+	ErrorCodeNotFound            ErrorCode = "NOT_FOUND"
+	ErrorCodeNotImplemented      ErrorCode = "NOT_IMPLEMENTED"
+	ErrorCodeUpstreamError       ErrorCode = "UPSTREAM_ERROR"
+	ErrorCodeUpstreamTimeout     ErrorCode = "UPSTREAM_TIMEOUT"
+	ErrorCodeUpstreamUnavailable ErrorCode = "UPSTREAM_UNAVAILABLE"
+	ErrorCodeCircuitOpen         ErrorCode = "CIRCUIT_OPEN"
+	ErrorCodeInternal            ErrorCode = "INTERNAL_ERROR"
+)
+
+// CustomError is the error returned across layers. Err is the public message
+// sent to clients; cause keeps the internal detail (upstream bodies, network
+// errors) for logs and errors.Is/As, and never leaves the service.
 type CustomError struct {
-	StatusCode int    `json:"status_code"`
-	Err        string `json:"message"`
+	StatusCode int       `json:"status_code"`
+	Err        string    `json:"message"`
+	Code       ErrorCode `json:"-"`
+	cause      error
 }
 
 func NewCustomError(statusCode int, message string) *CustomError {
+	return NewCodedError(defaultErrorCode(statusCode), statusCode, message)
+}
+
+func NewCodedError(code ErrorCode, statusCode int, message string) *CustomError {
 	return &CustomError{
 		StatusCode: statusCode,
 		Err:        message,
+		Code:       code,
 	}
 }
 
+// WithCause attaches the internal reason of the error and returns e.
+func (e *CustomError) WithCause(cause error) *CustomError {
+	e.cause = cause
+	return e
+}
+
 func (e *CustomError) Error() string {
+	if e.cause != nil {
+		return e.Err + ": " + e.cause.Error()
+	}
 	return e.Err
 }
+
+func (e *CustomError) Unwrap() error {
+	return e.cause
+}
+
+func defaultErrorCode(statusCode int) ErrorCode {
+	switch statusCode {
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusNotImplemented:
+		return ErrorCodeNotImplemented
+	case http.StatusBadGateway:
+		return ErrorCodeUpstreamError
+	case http.StatusServiceUnavailable:
+		return ErrorCodeUpstreamUnavailable
+	case http.StatusGatewayTimeout:
+		return ErrorCodeUpstreamTimeout
+	}
+	if statusCode >= http.StatusBadRequest && statusCode < http.StatusInternalServerError {
+		return ErrorCodeInvalidRequest
+	}
+	return ErrorCodeInternal
+}
@@ -1,6 +1,10 @@
 package model_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/Berchon/weather-cloud-run/internal/business/model"
@@ -30,3 +34,38 @@ func TestError(t *testing.T) {
 		assert.Equal(t, want, got)
 	})
 }
+
+func TestNewCodedError(t *testing.T) {
+	t.Run("Should default the code from the status code When using NewCustomError", func(t *testing.T) {
+		assert.Equal(t, model.ErrorCodeNotFound, model.NewCustomError(http.StatusNotFound, "not found").Code)
+		assert.Equal(t, model.ErrorCodeInvalidRequest, model.NewCustomError(http.StatusBadRequest, "bad").Code)
+		assert.Equal(t, model.ErrorCodeUpstreamTimeout, model.NewCustomError(http.StatusGatewayTimeout, "slow").Code)
+		assert.Equal(t, model.ErrorCodeInternal, model.NewCustomError(http.StatusInternalServerError, "boom").Code)
+	})
+
+	t.Run("Should keep the given code When using NewCodedError", func(t *testing.T) {
+		got := model.NewCodedError(model.ErrorCodeZipCodeNotFound, http.StatusNotFound, "can not find zipcode")
+
+		assert.Equal(t, model.ErrorCodeZipCodeNotFound, got.Code)
+		assert.Equal(t, http.StatusNotFound, got.StatusCode)
+	})
+}
+
+func TestWithCause(t *testing.T) {
+	t.Run("Should expose the cause to errors.Is and Error When a cause is attached", func(t *testing.T) {
+		got := model.NewCustomError(http.StatusGatewayTimeout, "weather api did not answer in time").
+			WithCause(context.DeadlineExceeded)
+
+		assert.ErrorIs(t, got, context.DeadlineExceeded)
+		assert.Equal(t, "weather api did not answer in time", got.Err)
+		assert.Equal(t, "weather api did not answer in time: context deadline exceeded", got.Error())
+	})
+
+	t.Run("Should keep the code and cause out of the legacy JSON When marshalled", func(t *testing.T) {
+		got, err := json.Marshal(model.NewCustomError(http.StatusBadGateway, "unexpected error from weather api").
+			WithCause(errors.New("raw upstream body")))
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"status_code":502,"message":"unexpected error from weather api"}`, string(got))
+	})
+}
@@ -0,0 +1,57 @@
+package model
+
+// WeatherForecast is what weather providers return for the coming days, in
+// Celsius only; Forecast is its rendering in all three units.
+type WeatherForecast struct {
+	Location Location
+	Days     []WeatherForecastDay
+}
+
+type WeatherForecastDay struct {
+	Date       string
+	MinCelsius float64
+	MaxCelsius float64
+	AvgCelsius float64
+	Hours      []WeatherForecastHour
+}
+
+type WeatherForecastHour struct {
+	Time    string
+	Celsius float64
+}
+
+type Forecast struct {
+	Location Location      `json:"location"`
+	Days     []ForecastDay `json:"days"`
+}
+
+type ForecastDay struct {
+	Date  string         `json:"date"`
+	Min   Temperature    `json:"min"`
+	Max   Temperature    `json:"max"`
+	Avg   Temperature    `json:"avg"`
+	Hours []ForecastHour `json:"hours"`
+}
+
+type ForecastHour struct {
+	Time string `json:"time"`
+	Temperature
+}
+
+func NewForecast(weatherForecast WeatherForecast, options TemperatureOptions) Forecast {
+	days := make([]ForecastDay, 0, len(weatherForecast.Days))
+	for _, day := range weatherForecast.Days {
+		hours := make([]ForecastHour, 0, len(day.Hours))
+		for _, hour := range day.Hours {
+			hours = append(hours, ForecastHour{Time: hour.Time, Temperature: NewTemperature(hour.Celsius, options)})
+		}
+		days = append(days, ForecastDay{
+			Date:  day.Date,
+			Min:   NewTemperature(day.MinCelsius, options),
+			Max:   NewTemperature(day.MaxCelsius, options),
+			Avg:   NewTemperature(day.AvgCelsius, options),
+			Hours: hours,
+		})
+	}
+	return Forecast{Location: weatherForecast.Location, Days: days}
+}
@@ -0,0 +1,43 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewForecast(t *testing.T) {
+	t.Run("Should convert every temperature When given a provider forecast", func(t *testing.T) {
+		weatherForecast := model.WeatherForecast{
+			Location: model.Location{Name: "Porto Alegre"},
+			Days: []model.WeatherForecastDay{{
+				Date: "2025-07-01", MinCelsius: 10, MaxCelsius: 20, AvgCelsius: 15,
+				Hours: []model.WeatherForecastHour{{Time: "2025-07-01 00:00", Celsius: 11}},
+			}},
+		}
+
+		got := model.NewForecast(weatherForecast, model.DefaultTemperatureOptions())
+
+		assert.Equal(t, model.Forecast{
+			Location: model.Location{Name: "Porto Alegre"},
+			Days: []model.ForecastDay{{
+				Date: "2025-07-01",
+				Min:  model.Temperature{Celsius: 10, Fahrenheit: 50, Kelvin: 283},
+				Max:  model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293},
+				Avg:  model.Temperature{Celsius: 15, Fahrenheit: 59, Kelvin: 288},
+				Hours: []model.ForecastHour{{
+					Time:        "2025-07-01 00:00",
+					Temperature: model.Temperature{Celsius: 11, Fahrenheit: 51.8, Kelvin: 284},
+				}},
+			}},
+		}, got)
+	})
+
+	t.Run("Should return empty lists When the provider forecast has no days", func(t *testing.T) {
+		got := model.NewForecast(model.WeatherForecast{}, model.DefaultTemperatureOptions())
+
+		assert.NotNil(t, got.Days)
+		assert.Empty(t, got.Days)
+	})
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const historyDateLayout = "2006-01-02"
+
+// History is the temperature record of one past day.
+type History struct {
+	Location Location `json:"location"`
+	ForecastDay
+}
+
+// NewHistory converts a single day answer from a weather provider.
+func NewHistory(weatherForecast WeatherForecast, options TemperatureOptions) *History {
+	forecast := NewForecast(weatherForecast, options)
+	if len(forecast.Days) == 0 {
+		return nil
+	}
+	return &History{Location: forecast.Location, ForecastDay: forecast.Days[0]}
+}
+
+// BuildHistoryDate parses a YYYY-MM-DD date that must fall between maxDays
+// before today and today, both included.
+func BuildHistoryDate(stringDate string, today time.Time, maxDays int) (*time.Time, *CustomError) {
+	date, err := time.Parse(historyDateLayout, stringDate)
+	if err != nil {
+		return nil, NewCodedError(ErrorCodeInvalidDate, http.StatusUnprocessableEntity,
+			fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", stringDate))
+	}
+
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if date.After(today) {
+		return nil, NewCodedError(ErrorCodeInvalidDate, http.StatusUnprocessableEntity,
+			fmt.Sprintf("date %s is in the future", stringDate))
+	}
+	if date.Before(today.AddDate(0, 0, -maxDays)) {
+		return nil, NewCodedError(ErrorCodeInvalidDate, http.StatusUnprocessableEntity,
+			fmt.Sprintf("date %s is more than %d days ago", stringDate, maxDays))
+	}
+	return &date, nil
+}
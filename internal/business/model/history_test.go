@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildHistoryDate(t *testing.T) {
+	today := time.Date(2025, 7, 10, 15, 30, 0, 0, time.UTC)
+
+	t.Run("Should return the date When it is within range", func(t *testing.T) {
+		for _, value := range []string{"2025-07-10", "2025-07-03"} {
+			got, err := model.BuildHistoryDate(value, today, 7)
+
+			assert.Nil(t, err)
+			assert.Equal(t, value, got.Format("2006-01-02"))
+		}
+	})
+
+	t.Run("Should return an error When the date is malformed", func(t *testing.T) {
+		got, err := model.BuildHistoryDate("10/07/2025", today, 7)
+
+		assert.Nil(t, got)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+		assert.Equal(t, model.ErrorCodeInvalidDate, err.Code)
+		assert.Equal(t, `invalid date "10/07/2025", expected YYYY-MM-DD`, err.Error())
+	})
+
+	t.Run("Should return an error When the date is in the future", func(t *testing.T) {
+		got, err := model.BuildHistoryDate("2025-07-11", today, 7)
+
+		assert.Nil(t, got)
+		assert.Equal(t, "date 2025-07-11 is in the future", err.Error())
+	})
+
+	t.Run("Should return an error When the date is too old", func(t *testing.T) {
+		got, err := model.BuildHistoryDate("2025-07-02", today, 7)
+
+		assert.Nil(t, got)
+		assert.Equal(t, "date 2025-07-02 is more than 7 days ago", err.Error())
+	})
+}
+
+func TestNewHistory(t *testing.T) {
+	t.Run("Should flatten the single day When given a provider answer", func(t *testing.T) {
+		got := model.NewHistory(model.WeatherForecast{
+			Location: model.Location{Name: "Porto Alegre"},
+			Days:     []model.WeatherForecastDay{{Date: "2025-07-03", MinCelsius: 10, MaxCelsius: 20, AvgCelsius: 15}},
+		}, model.DefaultTemperatureOptions())
+
+		assert.Equal(t, "Porto Alegre", got.Location.Name)
+		assert.Equal(t, "2025-07-03", got.Date)
+		assert.Equal(t, model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293}, got.Max)
+	})
+
+	t.Run("Should return nil When the provider answer has no day", func(t *testing.T) {
+		assert.Nil(t, model.NewHistory(model.WeatherForecast{}, model.DefaultTemperatureOptions()))
+	})
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// IBGECode is the 7 digit code IBGE assigns to each municipality.
+type IBGECode string
+
+func BuildIBGECode(stringIBGECode string) (*IBGECode, *CustomError) {
+	ibgeCode := IBGECode(stringIBGECode)
+	if ibgeCode.IsValidIBGECode() {
+		return &ibgeCode, nil
+	}
+
+	return nil, NewCodedError(ErrorCodeInvalidIBGECode, http.StatusUnprocessableEntity, "invalid ibge code")
+}
+
+func (ibgeCode *IBGECode) IsValidIBGECode() bool {
+	regex := regexp.MustCompile(`^[1-5]\d{6}$`)
+	return regex.MatchString(ibgeCode.ToString())
+}
+
+func (ibgeCode *IBGECode) ToString() string {
+	return string(*ibgeCode)
+}
@@ -0,0 +1,27 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildIBGECode(t *testing.T) {
+	t.Run("Should return an ibge code When given a valid value", func(t *testing.T) {
+		got, err := model.BuildIBGECode("4314902")
+
+		assert.Nil(t, err)
+		assert.Equal(t, model.IBGECode("4314902"), *got)
+	})
+
+	t.Run("Should return an error When given an invalid value", func(t *testing.T) {
+		for _, value := range []string{"431490", "43149021", "abcdefg", "9314902"} {
+			got, err := model.BuildIBGECode(value)
+
+			assert.Nil(t, got)
+			assert.Equal(t, "invalid ibge code", err.Error())
+			assert.Equal(t, model.ErrorCodeInvalidIBGECode, err.Code)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package model
+
+import "math"
+
+const (
+	// RoundedKelvinOffset is the offset the service contract specifies,
+	// K = C + 273.
+	RoundedKelvinOffset = 273.0
+	// PreciseKelvinOffset is the physical offset, K = C + 273.15.
+	PreciseKelvinOffset = 273.15
+)
+
+type TemperatureOptions struct {
+	// PreciseKelvin switches Kelvin to the 273.15 offset.
+	PreciseKelvin bool
+	// Precision is the number of decimal places every scale is rounded to.
+	Precision uint
+}
+
+func DefaultTemperatureOptions() TemperatureOptions {
+	return TemperatureOptions{Precision: 1}
+}
+
+type Temperature struct {
+	Celsius    float64 `json:"temp_C"`
+	Fahrenheit float64 `json:"temp_F"`
+	Kelvin     float64 `json:"temp_K"`
+}
+
+func NewTemperature(celsius float64, options TemperatureOptions) Temperature {
+	kelvinOffset := RoundedKelvinOffset
+	if options.PreciseKelvin {
+		kelvinOffset = PreciseKelvinOffset
+	}
+
+	return Temperature{
+		Celsius:    roundToDecimalPlaces(celsius, options.Precision),
+		Fahrenheit: roundToDecimalPlaces(convertCelsiusToFahrenheit(celsius), options.Precision),
+		Kelvin:     roundToDecimalPlaces(convertCelsiusToKelvin(celsius, kelvinOffset), options.Precision),
+	}
+}
+
+func roundToDecimalPlaces(value float64, decimalPlaces uint) float64 {
+	factor := math.Pow(10, float64(decimalPlaces))
+	return math.Round(value*factor) / factor
+}
+
+func convertCelsiusToFahrenheit(celsius float64) float64 {
+	return celsius*1.8 + 32
+}
+
+func convertCelsiusToKelvin(celsius, offset float64) float64 {
+	return celsius + offset
+}
@@ -0,0 +1,24 @@
+package model
+
+// TemperatureResult is one entry of a batch answer: either the temperature
+// of the zip code or the error that prevented resolving it.
+type TemperatureResult struct {
+	ZipCode     string       `json:"zip_code"`
+	Temperature *Temperature `json:"temperature,omitempty"`
+	Error       *ResultError `json:"error,omitempty"`
+}
+
+// ResultError is the per item rendering of a CustomError.
+type ResultError struct {
+	StatusCode int       `json:"status_code"`
+	Code       ErrorCode `json:"code"`
+	Message    string    `json:"message"`
+}
+
+func NewResultError(err *CustomError) *ResultError {
+	return &ResultError{
+		StatusCode: err.StatusCode,
+		Code:       err.Code,
+		Message:    err.Err,
+	}
+}
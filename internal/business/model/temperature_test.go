@@ -0,0 +1,103 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundToDecimalPlaces(t *testing.T) {
+	t.Run("should round to 1 decimal place", func(t *testing.T) {
+		assert.Equal(t, 2.3, roundToDecimalPlaces(2.34, 1))
+		assert.Equal(t, 2.4, roundToDecimalPlaces(2.36, 1))
+	})
+
+	t.Run("should round to 2 decimal places", func(t *testing.T) {
+		assert.Equal(t, 2.35, roundToDecimalPlaces(2.345, 2))
+		assert.Equal(t, 2.35, roundToDecimalPlaces(2.346, 2))
+	})
+
+	t.Run("should round negative numbers", func(t *testing.T) {
+		assert.Equal(t, -2.3, roundToDecimalPlaces(-2.34, 1))
+		assert.Equal(t, -2.4, roundToDecimalPlaces(-2.36, 1))
+	})
+
+	t.Run("should handle zero decimal places", func(t *testing.T) {
+		assert.Equal(t, 2.0, roundToDecimalPlaces(2.4, 0))
+		assert.Equal(t, 3.0, roundToDecimalPlaces(2.5, 0))
+	})
+
+	t.Run("should handle large numbers", func(t *testing.T) {
+		assert.Equal(t, 123456789.12, roundToDecimalPlaces(123456789.1234, 2))
+	})
+
+	t.Run("should handle very small numbers", func(t *testing.T) {
+		assert.Equal(t, 0.0, roundToDecimalPlaces(0.00001, 2))
+	})
+
+	t.Run("should handle very large decimalPlaces", func(t *testing.T) {
+		assert.Equal(t, 1.2345, roundToDecimalPlaces(1.2345, 100))
+		assert.Equal(t, 1.0, roundToDecimalPlaces(1.2345, 0))
+	})
+}
+
+func TestConvertCelsiusToFahrenheit(t *testing.T) {
+	t.Run("should convert celsius to fahrenheit", func(t *testing.T) {
+		assert.InDelta(t, 32.0, convertCelsiusToFahrenheit(0), 0.0001)
+		assert.InDelta(t, 212.0, convertCelsiusToFahrenheit(100), 0.0001)
+		assert.InDelta(t, -40.0, convertCelsiusToFahrenheit(-40), 0.0001)
+	})
+
+	t.Run("should handle decimal celsius", func(t *testing.T) {
+		assert.InDelta(t, 98.6, convertCelsiusToFahrenheit(37), 0.0001)
+	})
+}
+
+func TestConvertCelsiusToKelvin(t *testing.T) {
+	t.Run("should convert celsius to kelvin", func(t *testing.T) {
+		assert.InDelta(t, 273.0, convertCelsiusToKelvin(0, RoundedKelvinOffset), 0.0001)
+		assert.InDelta(t, 373.0, convertCelsiusToKelvin(100, RoundedKelvinOffset), 0.0001)
+		assert.InDelta(t, 233.0, convertCelsiusToKelvin(-40, RoundedKelvinOffset), 0.0001)
+	})
+
+	t.Run("should handle decimal celsius", func(t *testing.T) {
+		assert.InDelta(t, 310.5, convertCelsiusToKelvin(37.5, RoundedKelvinOffset), 0.0001)
+	})
+
+	t.Run("should apply the precise offset", func(t *testing.T) {
+		assert.InDelta(t, 273.15, convertCelsiusToKelvin(0, PreciseKelvinOffset), 0.0001)
+	})
+
+	t.Run("should handle extreme values", func(t *testing.T) {
+		assert.InDelta(t, math.MaxFloat64+273, convertCelsiusToKelvin(math.MaxFloat64, RoundedKelvinOffset), 1e292)
+		assert.InDelta(t, math.SmallestNonzeroFloat64+273, convertCelsiusToKelvin(math.SmallestNonzeroFloat64, RoundedKelvinOffset), 1e-292)
+	})
+}
+
+func TestNewTemperature(t *testing.T) {
+	t.Run("should use K = C + 273 by default", func(t *testing.T) {
+		got := NewTemperature(25, DefaultTemperatureOptions())
+		assert.Equal(t, Temperature{Celsius: 25, Fahrenheit: 77, Kelvin: 298}, got)
+	})
+
+	t.Run("should use K = C + 273.15 when precise kelvin is enabled", func(t *testing.T) {
+		got := NewTemperature(25, TemperatureOptions{PreciseKelvin: true, Precision: 2})
+		assert.Equal(t, Temperature{Celsius: 25, Fahrenheit: 77, Kelvin: 298.15}, got)
+	})
+
+	t.Run("should round every scale to the configured precision", func(t *testing.T) {
+		got := NewTemperature(21.456, TemperatureOptions{Precision: 2})
+		assert.Equal(t, Temperature{Celsius: 21.46, Fahrenheit: 70.62, Kelvin: 294.46}, got)
+
+		got = NewTemperature(21.456, TemperatureOptions{Precision: 0})
+		assert.Equal(t, Temperature{Celsius: 21, Fahrenheit: 71, Kelvin: 294}, got)
+	})
+
+	t.Run("should marshal to the temp_C, temp_F and temp_K contract", func(t *testing.T) {
+		body, err := json.Marshal(NewTemperature(-10, DefaultTemperatureOptions()))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"temp_C":-10,"temp_F":14,"temp_K":263}`, string(body))
+	})
+}
@@ -0,0 +1,17 @@
+package model
+
+// Location describes where a weather provider resolved the requested city.
+// LocalTime is the provider's local time at that place, "2006-01-02 15:04".
+type Location struct {
+	Name      string  `json:"name"`
+	Region    string  `json:"region,omitempty"`
+	Country   string  `json:"country,omitempty"`
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+	LocalTime string  `json:"localtime,omitempty"`
+}
+
+type Weather struct {
+	Celsius  float64
+	Location Location
+}
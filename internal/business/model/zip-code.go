@@ -13,7 +13,15 @@ func BuildZipCode(stringZipCode string) (*ZipCode, *CustomError) {
 		return &zipCode, nil
 	}
 
-	return nil, NewCustomError(http.StatusUnprocessableEntity, "invalid zipcode")
+	return nil, NewInvalidZipCodeError()
+}
+
+func NewInvalidZipCodeError() *CustomError {
+	return NewCodedError(ErrorCodeInvalidZipCode, http.StatusUnprocessableEntity, "invalid zipcode")
+}
+
+func NewZipCodeNotFoundError() *CustomError {
+	return NewCodedError(ErrorCodeZipCodeNotFound, http.StatusNotFound, "can not find zipcode")
 }
 
 func (zipCode *ZipCode) IsValidZipCode() bool {
@@ -0,0 +1,36 @@
+//go:generate mockery --dir=. --output=./mock --name=GetAirQualityByZipCodeUsecase --structname=MockGetAirQualityByZipCodeUsecase --outpkg=mock --filename=get_air_quality_by_zip_code_usecase.go --disable-version-string
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+type GetAirQualityByZipCodeUsecase interface {
+	GetAirQualityByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.AirQualityReport, *model.CustomError)
+}
+
+type getAirQualityByZipCodeUsecase struct {
+	zipCodeAddressResolver
+	WeatherService gateway.WeatherService
+}
+
+// NewGetAirQualityByZipCodeUsecase logs to logger, or nowhere when it is nil.
+func NewGetAirQualityByZipCodeUsecase(viaCepService gateway.ViaCepService, weatherService gateway.WeatherService, logger *slog.Logger) GetAirQualityByZipCodeUsecase {
+	return &getAirQualityByZipCodeUsecase{
+		zipCodeAddressResolver: newZipCodeAddressResolver(viaCepService, logger),
+		WeatherService:         weatherService,
+	}
+}
+
+func (uc *getAirQualityByZipCodeUsecase) GetAirQualityByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.AirQualityReport, *model.CustomError) {
+	address, err := uc.address(ctx, zipCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.WeatherService.GetAirQualityByCity(ctx, address.ToCity())
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGetAirQualityByZipCodeUsecase(t *testing.T) {
+	t.Run("should accept nil dependencies", func(t *testing.T) {
+		uc := NewGetAirQualityByZipCodeUsecase(nil, nil, nil)
+		assert.NotNil(t, uc)
+	})
+}
+
+func TestGetAirQualityByZipCode(t *testing.T) {
+	zip := model.ZipCode("12345678")
+	ctx := context.Background()
+	viaCep := serviceMock.NewMockViaCepService(t)
+	weather := serviceMock.NewMockWeatherService(t)
+
+	t.Run("should return error if city is empty", func(t *testing.T) {
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(&model.Address{ZipCode: "12345678"}, nil).Once()
+		uc := NewGetAirQualityByZipCodeUsecase(viaCep, weather, nil)
+		result, err := uc.GetAirQualityByZipCode(ctx, zip)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "city field is empty")
+	})
+
+	t.Run("should return the air quality report", func(t *testing.T) {
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		report := &model.AirQualityReport{
+			Location:   model.Location{Name: "Porto Alegre"},
+			AirQuality: model.AirQuality{O3: 40.2, USEPAIndex: 2, USEPACategory: "Moderate"},
+			Alerts:     []model.WeatherAlert{{Headline: "Heavy rain", Severity: "Moderate"}},
+		}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetAirQualityByCity", ctx, address.ToCity()).Return(report, nil).Once()
+		uc := NewGetAirQualityByZipCodeUsecase(viaCep, weather, nil)
+		result, err := uc.GetAirQualityByZipCode(ctx, zip)
+		assert.Nil(t, err)
+		assert.Equal(t, report, result)
+	})
+}
@@ -0,0 +1,44 @@
+//go:generate mockery --dir=. --output=./mock --name=GetConditionsByZipCodeUsecase --structname=MockGetConditionsByZipCodeUsecase --outpkg=mock --filename=get_conditions_by_zip_code_usecase.go --disable-version-string
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+type GetConditionsByZipCodeUsecase interface {
+	GetConditionsByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Conditions, *model.CustomError)
+}
+
+type getConditionsByZipCodeUsecase struct {
+	zipCodeAddressResolver
+	WeatherService     gateway.WeatherService
+	TemperatureOptions model.TemperatureOptions
+}
+
+// NewGetConditionsByZipCodeUsecase logs to logger, or nowhere when it is nil.
+func NewGetConditionsByZipCodeUsecase(viaCepService gateway.ViaCepService, weatherService gateway.WeatherService, temperatureOptions model.TemperatureOptions, logger *slog.Logger) GetConditionsByZipCodeUsecase {
+	return &getConditionsByZipCodeUsecase{
+		zipCodeAddressResolver: newZipCodeAddressResolver(viaCepService, logger),
+		WeatherService:         weatherService,
+		TemperatureOptions:     temperatureOptions,
+	}
+}
+
+func (uc *getConditionsByZipCodeUsecase) GetConditionsByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Conditions, *model.CustomError) {
+	address, err := uc.address(ctx, zipCode)
+	if err != nil {
+		return nil, err
+	}
+
+	weatherConditions, err := uc.WeatherService.GetConditionsByCity(ctx, address.ToCity())
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := model.NewConditions(*weatherConditions, uc.TemperatureOptions)
+	return &conditions, nil
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGetConditionsByZipCodeUsecase(t *testing.T) {
+	t.Run("should accept nil dependencies", func(t *testing.T) {
+		uc := NewGetConditionsByZipCodeUsecase(nil, nil, model.DefaultTemperatureOptions(), nil)
+		assert.NotNil(t, uc)
+	})
+}
+
+func TestGetConditionsByZipCode(t *testing.T) {
+	zip := model.ZipCode("12345678")
+	ctx := context.Background()
+	viaCep := serviceMock.NewMockViaCepService(t)
+	weather := serviceMock.NewMockWeatherService(t)
+
+	t.Run("should return error if viaCepService returns error", func(t *testing.T) {
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(nil, model.NewCustomError(http.StatusNotFound, "can not find zipcode")).Once()
+		uc := NewGetConditionsByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetConditionsByZipCode(ctx, zip)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("should return the converted conditions", func(t *testing.T) {
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetConditionsByCity", ctx, address.ToCity()).
+			Return(&model.WeatherConditions{Celsius: 20, FeelsLikeCelsius: 18, WindKph: 10}, nil).Once()
+		uc := NewGetConditionsByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetConditionsByZipCode(ctx, zip)
+		assert.Nil(t, err)
+		if assert.NotNil(t, result) {
+			assert.Equal(t, model.Temperature{Celsius: 18, Fahrenheit: 64.4, Kelvin: 291}, result.FeelsLike)
+			assert.Equal(t, 6.2, result.Wind.Mph)
+		}
+	})
+}
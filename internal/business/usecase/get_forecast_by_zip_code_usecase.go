@@ -0,0 +1,44 @@
+//go:generate mockery --dir=. --output=./mock --name=GetForecastByZipCodeUsecase --structname=MockGetForecastByZipCodeUsecase --outpkg=mock --filename=get_forecast_by_zip_code_usecase.go --disable-version-string
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+type GetForecastByZipCodeUsecase interface {
+	GetForecastByZipCode(ctx context.Context, zipCode model.ZipCode, days int) (*model.Forecast, *model.CustomError)
+}
+
+type getForecastByZipCodeUsecase struct {
+	zipCodeAddressResolver
+	WeatherService     gateway.WeatherService
+	TemperatureOptions model.TemperatureOptions
+}
+
+// NewGetForecastByZipCodeUsecase logs to logger, or nowhere when it is nil.
+func NewGetForecastByZipCodeUsecase(viaCepService gateway.ViaCepService, weatherService gateway.WeatherService, temperatureOptions model.TemperatureOptions, logger *slog.Logger) GetForecastByZipCodeUsecase {
+	return &getForecastByZipCodeUsecase{
+		zipCodeAddressResolver: newZipCodeAddressResolver(viaCepService, logger),
+		WeatherService:         weatherService,
+		TemperatureOptions:     temperatureOptions,
+	}
+}
+
+func (uc *getForecastByZipCodeUsecase) GetForecastByZipCode(ctx context.Context, zipCode model.ZipCode, days int) (*model.Forecast, *model.CustomError) {
+	address, err := uc.address(ctx, zipCode)
+	if err != nil {
+		return nil, err
+	}
+
+	weatherForecast, err := uc.WeatherService.GetForecastByCity(ctx, address.ToCity(), days)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := model.NewForecast(*weatherForecast, uc.TemperatureOptions)
+	return &forecast, nil
+}
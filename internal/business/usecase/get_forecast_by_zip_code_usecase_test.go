@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGetForecastByZipCodeUsecase(t *testing.T) {
+	t.Run("should accept nil dependencies", func(t *testing.T) {
+		uc := NewGetForecastByZipCodeUsecase(nil, nil, model.DefaultTemperatureOptions(), nil)
+		assert.NotNil(t, uc)
+	})
+}
+
+func TestGetForecastByZipCode(t *testing.T) {
+	zip := model.ZipCode("12345678")
+	ctx := context.Background()
+	viaCep := serviceMock.NewMockViaCepService(t)
+	weather := serviceMock.NewMockWeatherService(t)
+
+	t.Run("should return error if city is empty", func(t *testing.T) {
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(&model.Address{ZipCode: "12345678"}, nil).Once()
+		uc := NewGetForecastByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetForecastByZipCode(ctx, zip, 3)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "city field is empty")
+	})
+
+	t.Run("should return error if weatherService returns error", func(t *testing.T) {
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetForecastByCity", ctx, address.ToCity(), 3).
+			Return(nil, model.NewCustomError(http.StatusNotImplemented, "open meteo does not support forecast")).Once()
+		uc := NewGetForecastByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetForecastByZipCode(ctx, zip, 3)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+	})
+
+	t.Run("should return the forecast in all units", func(t *testing.T) {
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetForecastByCity", ctx, address.ToCity(), 1).Return(&model.WeatherForecast{
+			Location: model.Location{Name: "Porto Alegre"},
+			Days:     []model.WeatherForecastDay{{Date: "2025-07-01", MinCelsius: 10, MaxCelsius: 20, AvgCelsius: 15}},
+		}, nil).Once()
+		uc := NewGetForecastByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetForecastByZipCode(ctx, zip, 1)
+		assert.Nil(t, err)
+		if assert.NotNil(t, result) && assert.Len(t, result.Days, 1) {
+			assert.Equal(t, "Porto Alegre", result.Location.Name)
+			assert.Equal(t, model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293}, result.Days[0].Max)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+//go:generate mockery --dir=. --output=./mock --name=GetHistoryByZipCodeUsecase --structname=MockGetHistoryByZipCodeUsecase --outpkg=mock --filename=get_history_by_zip_code_usecase.go --disable-version-string
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+type GetHistoryByZipCodeUsecase interface {
+	GetHistoryByZipCode(ctx context.Context, zipCode model.ZipCode, date time.Time) (*model.History, *model.CustomError)
+}
+
+type getHistoryByZipCodeUsecase struct {
+	zipCodeAddressResolver
+	WeatherService     gateway.WeatherService
+	TemperatureOptions model.TemperatureOptions
+}
+
+// NewGetHistoryByZipCodeUsecase logs to logger, or nowhere when it is nil.
+func NewGetHistoryByZipCodeUsecase(viaCepService gateway.ViaCepService, weatherService gateway.WeatherService, temperatureOptions model.TemperatureOptions, logger *slog.Logger) GetHistoryByZipCodeUsecase {
+	return &getHistoryByZipCodeUsecase{
+		zipCodeAddressResolver: newZipCodeAddressResolver(viaCepService, logger),
+		WeatherService:         weatherService,
+		TemperatureOptions:     temperatureOptions,
+	}
+}
+
+func (uc *getHistoryByZipCodeUsecase) GetHistoryByZipCode(ctx context.Context, zipCode model.ZipCode, date time.Time) (*model.History, *model.CustomError) {
+	address, err := uc.address(ctx, zipCode)
+	if err != nil {
+		return nil, err
+	}
+
+	weatherHistory, err := uc.WeatherService.GetHistoryByCity(ctx, address.ToCity(), date)
+	if err != nil {
+		return nil, err
+	}
+
+	history := model.NewHistory(*weatherHistory, uc.TemperatureOptions)
+	if history == nil {
+		return nil, model.NewCustomError(http.StatusNotFound, "no history for "+date.Format("2006-01-02"))
+	}
+	return history, nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGetHistoryByZipCodeUsecase(t *testing.T) {
+	t.Run("should accept nil dependencies", func(t *testing.T) {
+		uc := NewGetHistoryByZipCodeUsecase(nil, nil, model.DefaultTemperatureOptions(), nil)
+		assert.NotNil(t, uc)
+	})
+}
+
+func TestGetHistoryByZipCode(t *testing.T) {
+	zip := model.ZipCode("12345678")
+	ctx := context.Background()
+	date := time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC)
+	address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+	viaCep := serviceMock.NewMockViaCepService(t)
+	weather := serviceMock.NewMockWeatherService(t)
+
+	t.Run("should return 404 if the provider has no record of the day", func(t *testing.T) {
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetHistoryByCity", ctx, address.ToCity(), date).Return(&model.WeatherForecast{}, nil).Once()
+		uc := NewGetHistoryByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetHistoryByZipCode(ctx, zip, date)
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "no history for 2025-07-03", err.Error())
+	})
+
+	t.Run("should return the converted history of the day", func(t *testing.T) {
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetHistoryByCity", ctx, address.ToCity(), date).Return(&model.WeatherForecast{
+			Days: []model.WeatherForecastDay{{Date: "2025-07-03", MinCelsius: 8, MaxCelsius: 18, AvgCelsius: 12.5}},
+		}, nil).Once()
+		uc := NewGetHistoryByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetHistoryByZipCode(ctx, zip, date)
+		assert.Nil(t, err)
+		if assert.NotNil(t, result) {
+			assert.Equal(t, "2025-07-03", result.Date)
+			assert.Equal(t, model.Temperature{Celsius: 8, Fahrenheit: 46.4, Kelvin: 281}, result.Min)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+//go:generate mockery --dir=. --output=./mock --name=GetTemperatureByLocationUsecase --structname=MockGetTemperatureByLocationUsecase --outpkg=mock --filename=get_temperature_by_location_usecase.go --disable-version-string
+package usecase
+
+import (
+	"context"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+// GetTemperatureByLocationUsecase answers the temperature for callers that
+// already know where they are, skipping the zip code lookup.
+type GetTemperatureByLocationUsecase interface {
+	GetTemperatureByCity(ctx context.Context, city model.City) (*model.Temperature, *model.CustomError)
+	GetTemperatureByCoordinates(ctx context.Context, coordinates model.Coordinates) (*model.Temperature, *model.CustomError)
+	GetTemperatureByIBGECode(ctx context.Context, ibgeCode model.IBGECode) (*model.Temperature, *model.CustomError)
+}
+
+type getTemperatureByLocationUsecase struct {
+	CityService        gateway.CityService
+	WeatherService     gateway.WeatherService
+	TemperatureOptions model.TemperatureOptions
+}
+
+func NewGetTemperatureByLocationUsecase(cityService gateway.CityService, weatherService gateway.WeatherService, temperatureOptions model.TemperatureOptions) GetTemperatureByLocationUsecase {
+	return &getTemperatureByLocationUsecase{
+		CityService:        cityService,
+		WeatherService:     weatherService,
+		TemperatureOptions: temperatureOptions,
+	}
+}
+
+func (uc *getTemperatureByLocationUsecase) GetTemperatureByCity(ctx context.Context, city model.City) (*model.Temperature, *model.CustomError) {
+	return uc.temperature(ctx, city)
+}
+
+func (uc *getTemperatureByLocationUsecase) GetTemperatureByCoordinates(ctx context.Context, coordinates model.Coordinates) (*model.Temperature, *model.CustomError) {
+	return uc.temperature(ctx, model.City{Coordinates: &coordinates})
+}
+
+func (uc *getTemperatureByLocationUsecase) GetTemperatureByIBGECode(ctx context.Context, ibgeCode model.IBGECode) (*model.Temperature, *model.CustomError) {
+	city, err := uc.CityService.GetCityByIBGECode(ctx, ibgeCode)
+	if err != nil {
+		return nil, err
+	}
+	return uc.temperature(ctx, *city)
+}
+
+func (uc *getTemperatureByLocationUsecase) temperature(ctx context.Context, city model.City) (*model.Temperature, *model.CustomError) {
+	weather, err := uc.WeatherService.GetWeatherByCity(ctx, city)
+	if err != nil {
+		return nil, err
+	}
+
+	temperature := model.NewTemperature(weather.Celsius, uc.TemperatureOptions)
+	return &temperature, nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTemperatureByLocation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return the temperature of a city", func(t *testing.T) {
+		city := model.City{Name: "Porto Alegre", State: "RS"}
+		weather := serviceMock.NewMockWeatherService(t)
+		weather.On("GetWeatherByCity", ctx, city).Return(&model.Weather{Celsius: 20}, nil).Once()
+		uc := NewGetTemperatureByLocationUsecase(nil, weather, model.DefaultTemperatureOptions())
+
+		result, err := uc.GetTemperatureByCity(ctx, city)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293}, result)
+	})
+
+	t.Run("should query the weather by coordinates only", func(t *testing.T) {
+		coordinates := model.Coordinates{Latitude: -30.03, Longitude: -51.2}
+		weather := serviceMock.NewMockWeatherService(t)
+		weather.On("GetWeatherByCity", ctx, model.City{Coordinates: &coordinates}).Return(&model.Weather{Celsius: 25}, nil).Once()
+		uc := NewGetTemperatureByLocationUsecase(nil, weather, model.DefaultTemperatureOptions())
+
+		result, err := uc.GetTemperatureByCoordinates(ctx, coordinates)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 25.0, result.Celsius)
+	})
+
+	t.Run("should resolve the ibge code before querying the weather", func(t *testing.T) {
+		city := &model.City{Name: "Porto Alegre", State: "RS", IBGECode: "4314902"}
+		cities := serviceMock.NewMockCityService(t)
+		cities.On("GetCityByIBGECode", ctx, model.IBGECode("4314902")).Return(city, nil).Once()
+		weather := serviceMock.NewMockWeatherService(t)
+		weather.On("GetWeatherByCity", ctx, *city).Return(&model.Weather{Celsius: 10}, nil).Once()
+		uc := NewGetTemperatureByLocationUsecase(cities, weather, model.DefaultTemperatureOptions())
+
+		result, err := uc.GetTemperatureByIBGECode(ctx, "4314902")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 10.0, result.Celsius)
+	})
+
+	t.Run("should return error if cityService returns error", func(t *testing.T) {
+		cities := serviceMock.NewMockCityService(t)
+		cities.On("GetCityByIBGECode", ctx, model.IBGECode("4399999")).
+			Return(nil, model.NewCustomError(http.StatusNotFound, "can not find city")).Once()
+		weather := serviceMock.NewMockWeatherService(t)
+		uc := NewGetTemperatureByLocationUsecase(cities, weather, model.DefaultTemperatureOptions())
+
+		result, err := uc.GetTemperatureByIBGECode(ctx, "4399999")
+
+		assert.Nil(t, result)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("should return error if weatherService returns error", func(t *testing.T) {
+		city := model.City{Name: "Porto Alegre", State: "RS"}
+		weather := serviceMock.NewMockWeatherService(t)
+		weather.On("GetWeatherByCity", ctx, city).Return(nil, model.NewCustomError(http.StatusBadGateway, "weather error")).Once()
+		uc := NewGetTemperatureByLocationUsecase(nil, weather, model.DefaultTemperatureOptions())
+
+		result, err := uc.GetTemperatureByCity(ctx, city)
+
+		assert.Nil(t, result)
+		assert.Equal(t, "weather error", err.Error())
+	})
+}
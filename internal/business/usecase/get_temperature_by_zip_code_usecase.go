@@ -3,64 +3,78 @@ package usecase
 
 import (
 	"context"
-	"fmt"
-	"math"
-	"net/http"
+	"log/slog"
 
 	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
 	"github.com/Berchon/weather-cloud-run/internal/business/model"
 )
 
 type GetTemperatureByZipCodeUsecase interface {
-	GetTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode) (*map[string]float64, *model.CustomError)
+	GetTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Temperature, *model.CustomError)
+	GetDetailedTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode, includes model.TemperatureIncludes) (*model.DetailedTemperature, *model.CustomError)
 }
 
 type getTemperatureByZipCodeUsecase struct {
-	ViaCepService  gateway.ViaCepService
-	WeatherService gateway.WeatherService
+	zipCodeAddressResolver
+	WeatherService     gateway.WeatherService
+	TemperatureOptions model.TemperatureOptions
 }
 
-func NewGetTemperatureByZipCodeUsecase(viaCepService gateway.ViaCepService, weatherService gateway.WeatherService) GetTemperatureByZipCodeUsecase {
+// NewGetTemperatureByZipCodeUsecase logs to logger, or nowhere when it is nil.
+func NewGetTemperatureByZipCodeUsecase(viaCepService gateway.ViaCepService, weatherService gateway.WeatherService, temperatureOptions model.TemperatureOptions, logger *slog.Logger) GetTemperatureByZipCodeUsecase {
 	return &getTemperatureByZipCodeUsecase{
-		ViaCepService:  viaCepService,
-		WeatherService: weatherService,
+		zipCodeAddressResolver: newZipCodeAddressResolver(viaCepService, logger),
+		WeatherService:         weatherService,
+		TemperatureOptions:     temperatureOptions,
 	}
 }
 
-func (uc *getTemperatureByZipCodeUsecase) GetTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode) (*map[string]float64, *model.CustomError) {
-	city, err := uc.ViaCepService.GetAddressByZipCode(ctx, zipCode)
-	fmt.Println("Error: ", err)
+func (uc *getTemperatureByZipCodeUsecase) GetTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Temperature, *model.CustomError) {
+	_, weather, err := uc.resolve(ctx, zipCode)
 	if err != nil {
 		return nil, err
 	}
 
-	if *city == "" {
-		return nil, model.NewCustomError(http.StatusInternalServerError, "city field is empty in response from via cep service")
-	}
+	temperature := model.NewTemperature(weather.Celsius, uc.TemperatureOptions)
+	return &temperature, nil
+}
 
-	celcius, err := uc.WeatherService.GetWeatherByCity(ctx, *city)
+// GetDetailedTemperatureByZipCode adds the blocks asked for to the
+// temperature. The air quality is looked up only when asked for, and a failure
+// to get it fails the whole request rather than silently dropping the block.
+func (uc *getTemperatureByZipCodeUsecase) GetDetailedTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode, includes model.TemperatureIncludes) (*model.DetailedTemperature, *model.CustomError) {
+	address, weather, err := uc.resolve(ctx, zipCode)
 	if err != nil {
 		return nil, err
 	}
 
-	result := map[string]float64{
-		"temp_C": roundToDecimalPlaces(*celcius, 1),
-		"temp_F": roundToDecimalPlaces(convertCelsiusToFahrenheit(*celcius), 1),
-		"temp_K": roundToDecimalPlaces(convertCelsiusToKelvin(*celcius), 1),
+	detailed := &model.DetailedTemperature{
+		Temperature: model.NewTemperature(weather.Celsius, uc.TemperatureOptions),
 	}
-
-	return &result, nil
+	if includes.Location {
+		detailed.Address = address
+		detailed.Location = &weather.Location
+	}
+	if includes.AirQuality {
+		report, err := uc.WeatherService.GetAirQualityByCity(ctx, address.ToCity())
+		if err != nil {
+			return nil, err
+		}
+		detailed.AirQuality = report
+	}
+	return detailed, nil
 }
 
-func roundToDecimalPlaces(value float64, decimalPlaces uint) float64 {
-	factor := math.Pow(10, float64(decimalPlaces))
-	return math.Round(value*factor) / factor
-}
+func (uc *getTemperatureByZipCodeUsecase) resolve(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.Weather, *model.CustomError) {
+	address, err := uc.address(ctx, zipCode)
+	if err != nil {
+		return nil, nil, err
+	}
 
-func convertCelsiusToFahrenheit(celsius float64) float64 {
-	return celsius*1.8 + 32
-}
+	weather, err := uc.WeatherService.GetWeatherByCity(ctx, address.ToCity())
+	if err != nil {
+		return nil, nil, err
+	}
 
-func convertCelsiusToKelvin(celsius float64) float64 {
-	return celsius + 273 // 273.15
+	return address, weather, nil
 }
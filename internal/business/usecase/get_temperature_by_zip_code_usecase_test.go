@@ -1,8 +1,10 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
-	"math"
+	"encoding/json"
+	"log/slog"
 	"net/http"
 	"testing"
 
@@ -10,82 +12,19 @@ import (
 	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestRoundToDecimalPlaces(t *testing.T) {
-	t.Run("should round to 1 decimal place", func(t *testing.T) {
-		assert.Equal(t, 2.3, roundToDecimalPlaces(2.34, 1))
-		assert.Equal(t, 2.4, roundToDecimalPlaces(2.36, 1))
-	})
-
-	t.Run("should round to 2 decimal places", func(t *testing.T) {
-		assert.Equal(t, 2.35, roundToDecimalPlaces(2.345, 2))
-		assert.Equal(t, 2.35, roundToDecimalPlaces(2.346, 2))
-	})
-
-	t.Run("should round negative numbers", func(t *testing.T) {
-		assert.Equal(t, -2.3, roundToDecimalPlaces(-2.34, 1))
-		assert.Equal(t, -2.4, roundToDecimalPlaces(-2.36, 1))
-	})
-
-	t.Run("should handle zero decimal places", func(t *testing.T) {
-		assert.Equal(t, 2.0, roundToDecimalPlaces(2.4, 0))
-		assert.Equal(t, 3.0, roundToDecimalPlaces(2.5, 0))
-	})
-
-	t.Run("should handle large numbers", func(t *testing.T) {
-		assert.Equal(t, 123456789.12, roundToDecimalPlaces(123456789.1234, 2))
-	})
-
-	t.Run("should handle very small numbers", func(t *testing.T) {
-		assert.Equal(t, 0.0, roundToDecimalPlaces(0.00001, 2))
-	})
-
-	t.Run("should handle very large decimalPlaces", func(t *testing.T) {
-		assert.Equal(t, 1.2345, roundToDecimalPlaces(1.2345, 100))
-		assert.Equal(t, 1.0, roundToDecimalPlaces(1.2345, 0))
-	})
-}
-
-func TestConvertCelsiusToFahrenheit(t *testing.T) {
-	t.Run("should convert celsius to fahrenheit", func(t *testing.T) {
-		assert.InDelta(t, 32.0, convertCelsiusToFahrenheit(0), 0.0001)
-		assert.InDelta(t, 212.0, convertCelsiusToFahrenheit(100), 0.0001)
-		assert.InDelta(t, -40.0, convertCelsiusToFahrenheit(-40), 0.0001)
-	})
-
-	t.Run("should handle decimal celsius", func(t *testing.T) {
-		assert.InDelta(t, 98.6, convertCelsiusToFahrenheit(37), 0.0001)
-	})
-}
-
-func TestConvertCelsiusToKelvin(t *testing.T) {
-	t.Run("should convert celsius to kelvin", func(t *testing.T) {
-		assert.InDelta(t, 273.0, convertCelsiusToKelvin(0), 0.0001)
-		assert.InDelta(t, 373.0, convertCelsiusToKelvin(100), 0.0001)
-		assert.InDelta(t, 233.0, convertCelsiusToKelvin(-40), 0.0001)
-	})
-
-	t.Run("should handle decimal celsius", func(t *testing.T) {
-		assert.InDelta(t, 310.5, convertCelsiusToKelvin(37.5), 0.0001)
-	})
-
-	t.Run("should handle extreme values", func(t *testing.T) {
-		assert.InDelta(t, math.MaxFloat64+273, convertCelsiusToKelvin(math.MaxFloat64), 1e292)
-		assert.InDelta(t, math.SmallestNonzeroFloat64+273, convertCelsiusToKelvin(math.SmallestNonzeroFloat64), 1e-292)
-	})
-}
-
 func TestNewGetTemperatureByZipCodeUsecase(t *testing.T) {
 	t.Run("should create usecase with valid dependencies", func(t *testing.T) {
 		viaCep := serviceMock.NewMockViaCepService(t)
 		weather := serviceMock.NewMockWeatherService(t)
-		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather)
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
 		assert.NotNil(t, uc)
 	})
 
 	t.Run("should accept nil dependencies (not recommended, but possible)", func(t *testing.T) {
-		uc := NewGetTemperatureByZipCodeUsecase(nil, nil)
+		uc := NewGetTemperatureByZipCodeUsecase(nil, nil, model.DefaultTemperatureOptions(), nil)
 		assert.NotNil(t, uc)
 	})
 }
@@ -98,17 +37,34 @@ func TestGetTemperatureByZipCode(t *testing.T) {
 
 	t.Run("should return error if viaCepService returns error", func(t *testing.T) {
 		viaCep.On("GetAddressByZipCode", ctx, zip).Return(nil, model.NewCustomError(http.StatusInternalServerError, "via cep error")).Once()
-		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather)
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
 		result, err := uc.GetTemperatureByZipCode(ctx, zip)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), "via cep error")
 	})
 
+	t.Run("should log server side zip code errors only", func(t *testing.T) {
+		var out bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&out, nil))
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(nil, model.NewZipCodeNotFoundError()).Once()
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(nil, model.NewCustomError(http.StatusInternalServerError, "via cep error")).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), logger)
+
+		_, _ = uc.GetTemperatureByZipCode(ctx, zip)
+		_, _ = uc.GetTemperatureByZipCode(ctx, zip)
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+		assert.Equal(t, "ERROR", entry["level"])
+		assert.Equal(t, "zip code lookup failed", entry["msg"])
+		assert.Equal(t, "via cep error", entry["error"])
+		assert.Equal(t, float64(http.StatusInternalServerError), entry["status_code"])
+	})
+
 	t.Run("should return error if city is empty", func(t *testing.T) {
-		empty := ""
-		viaCep.On("GetAddressByZipCode", ctx, zip).Return(&empty, nil).Once()
-		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather)
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(&model.Address{ZipCode: "12345678"}, nil).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
 		result, err := uc.GetTemperatureByZipCode(ctx, zip)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -116,10 +72,10 @@ func TestGetTemperatureByZipCode(t *testing.T) {
 	})
 
 	t.Run("should return error if weatherService returns error", func(t *testing.T) {
-		city := "Porto Alegre"
-		viaCep.On("GetAddressByZipCode", ctx, zip).Return(&city, nil).Once()
-		weather.On("GetWeatherByCity", ctx, city).Return(nil, model.NewCustomError(http.StatusInternalServerError, "weather error")).Once()
-		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather)
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetWeatherByCity", ctx, address.ToCity()).Return(nil, model.NewCustomError(http.StatusInternalServerError, "weather error")).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
 		result, err := uc.GetTemperatureByZipCode(ctx, zip)
 		assert.Nil(t, result)
 		assert.NotNil(t, err)
@@ -127,30 +83,104 @@ func TestGetTemperatureByZipCode(t *testing.T) {
 	})
 
 	t.Run("should return temperatures when all services succeed", func(t *testing.T) {
-		city := "Porto Alegre"
-		viaCep.On("GetAddressByZipCode", ctx, zip).Return(&city, nil).Once()
-		temp := 25.0
-		weather.On("GetWeatherByCity", ctx, city).Return(&temp, nil).Once()
-		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather)
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		temp := &model.Weather{Celsius: 25.0}
+		weather.On("GetWeatherByCity", ctx, address.ToCity()).Return(temp, nil).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
 		result, err := uc.GetTemperatureByZipCode(ctx, zip)
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
-		assert.InDelta(t, 25.0, (*result)["temp_C"], 0.01)
-		assert.InDelta(t, 77.0, (*result)["temp_F"], 0.01)
-		assert.InDelta(t, 298.0, (*result)["temp_K"], 0.01)
+		assert.InDelta(t, 25.0, result.Celsius, 0.01)
+		assert.InDelta(t, 77.0, result.Fahrenheit, 0.01)
+		assert.InDelta(t, 298.0, result.Kelvin, 0.01)
 	})
 
 	t.Run("should handle negative temperature", func(t *testing.T) {
-		city := "Porto Alegre"
-		temp := -10.0
-		viaCep.On("GetAddressByZipCode", ctx, zip).Return(&city, nil).Once()
-		weather.On("GetWeatherByCity", ctx, city).Return(&temp, nil).Once()
-		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather)
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		temp := &model.Weather{Celsius: -10.0}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetWeatherByCity", ctx, address.ToCity()).Return(temp, nil).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
 		result, err := uc.GetTemperatureByZipCode(ctx, zip)
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
-		assert.InDelta(t, -10.0, (*result)["temp_C"], 0.01)
-		assert.InDelta(t, 14.0, (*result)["temp_F"], 0.01)
-		assert.InDelta(t, 263.0, (*result)["temp_K"], 0.01)
+		assert.InDelta(t, -10.0, result.Celsius, 0.01)
+		assert.InDelta(t, 14.0, result.Fahrenheit, 0.01)
+		assert.InDelta(t, 263.0, result.Kelvin, 0.01)
+	})
+
+	t.Run("should apply temperature options", func(t *testing.T) {
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetWeatherByCity", ctx, address.ToCity()).Return(&model.Weather{Celsius: 25.0}, nil).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.TemperatureOptions{PreciseKelvin: true, Precision: 2}, nil)
+		result, err := uc.GetTemperatureByZipCode(ctx, zip)
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+		assert.InDelta(t, 298.15, result.Kelvin, 0.0001)
+	})
+}
+
+func TestGetDetailedTemperatureByZipCode(t *testing.T) {
+	zip := model.ZipCode("12345678")
+	ctx := context.Background()
+	viaCep := serviceMock.NewMockViaCepService(t)
+	weather := serviceMock.NewMockWeatherService(t)
+
+	t.Run("should return error if viaCepService returns error", func(t *testing.T) {
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(nil, model.NewCustomError(http.StatusNotFound, "can not find zipcode")).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetDetailedTemperatureByZipCode(ctx, zip, model.TemperatureIncludes{Location: true})
+		assert.Nil(t, result)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("should return temperatures with address and location", func(t *testing.T) {
+		address := &model.Address{ZipCode: "12345-678", City: "Porto Alegre", State: "RS", IBGECode: "4314902"}
+		location := model.Location{Name: "Porto Alegre", Region: "Rio Grande do Sul", Country: "Brazil", Latitude: -30.03, Longitude: -51.2}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetWeatherByCity", ctx, address.ToCity()).Return(&model.Weather{Celsius: 25.0, Location: location}, nil).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetDetailedTemperatureByZipCode(ctx, zip, model.TemperatureIncludes{Location: true})
+		assert.Nil(t, err)
+		if assert.NotNil(t, result) {
+			assert.Equal(t, model.DetailedTemperature{
+				Temperature: model.Temperature{Celsius: 25.0, Fahrenheit: 77.0, Kelvin: 298.0},
+				Address:     address,
+				Location:    &location,
+			}, *result)
+		}
+	})
+
+	t.Run("should return temperatures with air quality only", func(t *testing.T) {
+		address := &model.Address{ZipCode: "12345-678", City: "Porto Alegre", State: "RS"}
+		report := &model.AirQualityReport{AirQuality: model.AirQuality{PM2_5: 8.5, USEPAIndex: 1}, Alerts: []model.WeatherAlert{}}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetWeatherByCity", ctx, address.ToCity()).Return(&model.Weather{Celsius: 25.0}, nil).Once()
+		weather.On("GetAirQualityByCity", ctx, address.ToCity()).Return(report, nil).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetDetailedTemperatureByZipCode(ctx, zip, model.TemperatureIncludes{AirQuality: true})
+		assert.Nil(t, err)
+		if assert.NotNil(t, result) {
+			assert.Nil(t, result.Address)
+			assert.Nil(t, result.Location)
+			assert.Equal(t, report, result.AirQuality)
+		}
+	})
+
+	t.Run("should return error if air quality lookup fails", func(t *testing.T) {
+		address := &model.Address{ZipCode: "12345-678", City: "Porto Alegre", State: "RS"}
+		viaCep.On("GetAddressByZipCode", ctx, zip).Return(address, nil).Once()
+		weather.On("GetWeatherByCity", ctx, address.ToCity()).Return(&model.Weather{Celsius: 25.0}, nil).Once()
+		weather.On("GetAirQualityByCity", ctx, address.ToCity()).
+			Return(nil, model.NewCustomError(http.StatusNotImplemented, "open meteo does not support air quality")).Once()
+		uc := NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil)
+		result, err := uc.GetDetailedTemperatureByZipCode(ctx, zip, model.TemperatureIncludes{AirQuality: true})
+		assert.Nil(t, result)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+		}
 	})
 }
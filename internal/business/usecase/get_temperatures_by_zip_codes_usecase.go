@@ -0,0 +1,93 @@
+//go:generate mockery --dir=. --output=./mock --name=GetTemperaturesByZipCodesUsecase --structname=MockGetTemperaturesByZipCodesUsecase --outpkg=mock --filename=get_temperatures_by_zip_codes_usecase.go --disable-version-string
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+type GetTemperaturesByZipCodesUsecase interface {
+	GetTemperaturesByZipCodes(ctx context.Context, zipCodes []string) []model.TemperatureResult
+}
+
+type getTemperaturesByZipCodesUsecase struct {
+	GetTemperatureByZipCodeUsecase GetTemperatureByZipCodeUsecase
+	Workers                        int
+}
+
+// NewGetTemperaturesByZipCodesUsecase resolves batches through the single zip
+// code usecase, with at most workers lookups in flight.
+func NewGetTemperaturesByZipCodesUsecase(getTemperatureByZipCodeUsecase GetTemperatureByZipCodeUsecase, workers int) GetTemperaturesByZipCodesUsecase {
+	if workers < 1 {
+		workers = 1
+	}
+	return &getTemperaturesByZipCodesUsecase{
+		GetTemperatureByZipCodeUsecase: getTemperatureByZipCodeUsecase,
+		Workers:                        workers,
+	}
+}
+
+// GetTemperaturesByZipCodes returns one result per distinct zip code, in the
+// order they first appear. "12345-678" and "12345678" are the same zip code.
+// Invalid zip codes get an error result and are never looked up.
+func (uc *getTemperaturesByZipCodesUsecase) GetTemperaturesByZipCodes(ctx context.Context, zipCodes []string) []model.TemperatureResult {
+	results := []model.TemperatureResult{}
+	pending := map[int]model.ZipCode{}
+	seen := map[string]bool{}
+
+	for _, stringZipCode := range zipCodes {
+		stringZipCode = strings.TrimSpace(stringZipCode)
+		zipCode, err := model.BuildZipCode(stringZipCode)
+
+		key := stringZipCode
+		if err == nil {
+			key = strings.ReplaceAll(stringZipCode, "-", "")
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		result := model.TemperatureResult{ZipCode: stringZipCode}
+		if err != nil {
+			result.Error = model.NewResultError(err)
+		} else {
+			pending[len(results)] = *zipCode
+		}
+		results = append(results, result)
+	}
+
+	uc.resolve(ctx, results, pending)
+	return results
+}
+
+// resolve fills results[i] for every index of pending using a bounded pool of
+// workers. Each worker writes to its own index, so no locking is needed.
+func (uc *getTemperaturesByZipCodesUsecase) resolve(ctx context.Context, results []model.TemperatureResult, pending map[int]model.ZipCode) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for range min(uc.Workers, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				temperature, err := uc.GetTemperatureByZipCodeUsecase.GetTemperatureByZipCode(ctx, pending[i])
+				if err != nil {
+					results[i].Error = model.NewResultError(err)
+					continue
+				}
+				results[i].Temperature = temperature
+			}
+		}()
+	}
+
+	for i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
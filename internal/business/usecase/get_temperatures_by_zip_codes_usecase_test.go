@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubTemperatureUsecase answers from a map and records how many lookups ran
+// and how many were in flight at once.
+type stubTemperatureUsecase struct {
+	temperatures map[model.ZipCode]float64
+	delay        time.Duration
+	calls        atomic.Int32
+	inFlight     atomic.Int32
+	mu           sync.Mutex
+	maxInFlight  int32
+}
+
+func (s *stubTemperatureUsecase) GetTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Temperature, *model.CustomError) {
+	s.calls.Add(1)
+	current := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	s.mu.Lock()
+	s.maxInFlight = max(s.maxInFlight, current)
+	s.mu.Unlock()
+	time.Sleep(s.delay)
+
+	celsius, ok := s.temperatures[zipCode]
+	if !ok {
+		return nil, model.NewZipCodeNotFoundError()
+	}
+	temperature := model.NewTemperature(celsius, model.DefaultTemperatureOptions())
+	return &temperature, nil
+}
+
+func (s *stubTemperatureUsecase) GetDetailedTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode, includes model.TemperatureIncludes) (*model.DetailedTemperature, *model.CustomError) {
+	return nil, model.NewCustomError(http.StatusNotImplemented, "not used")
+}
+
+func (s *stubTemperatureUsecase) GetConditionsByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Conditions, *model.CustomError) {
+	return nil, model.NewCustomError(http.StatusNotImplemented, "not used")
+}
+
+func (s *stubTemperatureUsecase) GetForecastByZipCode(ctx context.Context, zipCode model.ZipCode, days int) (*model.Forecast, *model.CustomError) {
+	return nil, model.NewCustomError(http.StatusNotImplemented, "not used")
+}
+
+func (s *stubTemperatureUsecase) GetHistoryByZipCode(ctx context.Context, zipCode model.ZipCode, date time.Time) (*model.History, *model.CustomError) {
+	return nil, model.NewCustomError(http.StatusNotImplemented, "not used")
+}
+
+func (s *stubTemperatureUsecase) GetAirQualityByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.AirQualityReport, *model.CustomError) {
+	return nil, model.NewCustomError(http.StatusNotImplemented, "not used")
+}
+
+func TestGetTemperaturesByZipCodes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should return one result per zip code in input order", func(t *testing.T) {
+		stub := &stubTemperatureUsecase{temperatures: map[model.ZipCode]float64{"01001000": 20, "90010-000": 15}}
+		uc := NewGetTemperaturesByZipCodesUsecase(stub, 2)
+
+		results := uc.GetTemperaturesByZipCodes(ctx, []string{"01001000", "abc", "90010-000", "99999999"})
+
+		if assert.Len(t, results, 4) {
+			assert.Equal(t, "01001000", results[0].ZipCode)
+			assert.Equal(t, 20.0, results[0].Temperature.Celsius)
+			assert.Nil(t, results[0].Error)
+
+			assert.Equal(t, "abc", results[1].ZipCode)
+			assert.Nil(t, results[1].Temperature)
+			assert.Equal(t, &model.ResultError{StatusCode: http.StatusUnprocessableEntity, Code: model.ErrorCodeInvalidZipCode, Message: "invalid zipcode"}, results[1].Error)
+
+			assert.Equal(t, 15.0, results[2].Temperature.Celsius)
+
+			assert.Nil(t, results[3].Temperature)
+			assert.Equal(t, model.ErrorCodeZipCodeNotFound, results[3].Error.Code)
+		}
+		assert.Equal(t, int32(3), stub.calls.Load())
+	})
+
+	t.Run("should look up duplicated zip codes only once", func(t *testing.T) {
+		stub := &stubTemperatureUsecase{temperatures: map[model.ZipCode]float64{"01001000": 20}}
+		uc := NewGetTemperaturesByZipCodesUsecase(stub, 4)
+
+		results := uc.GetTemperaturesByZipCodes(ctx, []string{"01001000", "01001-000", " 01001000 ", "abc", "abc"})
+
+		if assert.Len(t, results, 2) {
+			assert.Equal(t, "01001000", results[0].ZipCode)
+			assert.Equal(t, "abc", results[1].ZipCode)
+		}
+		assert.Equal(t, int32(1), stub.calls.Load())
+	})
+
+	t.Run("should not run more lookups at once than workers", func(t *testing.T) {
+		stub := &stubTemperatureUsecase{temperatures: map[model.ZipCode]float64{}, delay: 10 * time.Millisecond}
+		uc := NewGetTemperaturesByZipCodesUsecase(stub, 3)
+
+		zipCodes := []string{}
+		for i := range 12 {
+			zipCodes = append(zipCodes, fmt.Sprintf("%08d", i))
+		}
+
+		results := uc.GetTemperaturesByZipCodes(ctx, zipCodes)
+
+		assert.Len(t, results, 12)
+		assert.Equal(t, int32(12), stub.calls.Load())
+		assert.LessOrEqual(t, stub.maxInFlight, int32(3))
+		assert.Greater(t, stub.maxInFlight, int32(1))
+	})
+
+	t.Run("should use a single worker when workers is not positive", func(t *testing.T) {
+		stub := &stubTemperatureUsecase{temperatures: map[model.ZipCode]float64{}}
+		uc := NewGetTemperaturesByZipCodesUsecase(stub, 0)
+
+		results := uc.GetTemperaturesByZipCodes(ctx, []string{"01001000", "02002000"})
+
+		assert.Len(t, results, 2)
+		assert.Equal(t, int32(1), stub.maxInFlight)
+	})
+}
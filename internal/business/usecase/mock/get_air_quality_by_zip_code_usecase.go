@@ -0,0 +1,61 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	model "github.com/Berchon/weather-cloud-run/internal/business/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetAirQualityByZipCodeUsecase is an autogenerated mock type for the GetAirQualityByZipCodeUsecase type
+type MockGetAirQualityByZipCodeUsecase struct {
+	mock.Mock
+}
+
+// GetAirQualityByZipCode provides a mock function with given fields: ctx, zipCode
+func (_m *MockGetAirQualityByZipCodeUsecase) GetAirQualityByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.AirQualityReport, *model.CustomError) {
+	ret := _m.Called(ctx, zipCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAirQualityByZipCode")
+	}
+
+	var r0 *model.AirQualityReport
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) (*model.AirQualityReport, *model.CustomError)); ok {
+		return rf(ctx, zipCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) *model.AirQualityReport); ok {
+		r0 = rf(ctx, zipCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AirQualityReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.ZipCode) *model.CustomError); ok {
+		r1 = rf(ctx, zipCode)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewMockGetAirQualityByZipCodeUsecase creates a new instance of MockGetAirQualityByZipCodeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetAirQualityByZipCodeUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetAirQualityByZipCodeUsecase {
+	mock := &MockGetAirQualityByZipCodeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
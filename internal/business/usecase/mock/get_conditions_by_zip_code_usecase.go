@@ -0,0 +1,61 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	model "github.com/Berchon/weather-cloud-run/internal/business/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetConditionsByZipCodeUsecase is an autogenerated mock type for the GetConditionsByZipCodeUsecase type
+type MockGetConditionsByZipCodeUsecase struct {
+	mock.Mock
+}
+
+// GetConditionsByZipCode provides a mock function with given fields: ctx, zipCode
+func (_m *MockGetConditionsByZipCodeUsecase) GetConditionsByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Conditions, *model.CustomError) {
+	ret := _m.Called(ctx, zipCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConditionsByZipCode")
+	}
+
+	var r0 *model.Conditions
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) (*model.Conditions, *model.CustomError)); ok {
+		return rf(ctx, zipCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) *model.Conditions); ok {
+		r0 = rf(ctx, zipCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Conditions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.ZipCode) *model.CustomError); ok {
+		r1 = rf(ctx, zipCode)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewMockGetConditionsByZipCodeUsecase creates a new instance of MockGetConditionsByZipCodeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetConditionsByZipCodeUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetConditionsByZipCodeUsecase {
+	mock := &MockGetConditionsByZipCodeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
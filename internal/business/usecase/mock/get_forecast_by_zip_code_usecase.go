@@ -0,0 +1,61 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	model "github.com/Berchon/weather-cloud-run/internal/business/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetForecastByZipCodeUsecase is an autogenerated mock type for the GetForecastByZipCodeUsecase type
+type MockGetForecastByZipCodeUsecase struct {
+	mock.Mock
+}
+
+// GetForecastByZipCode provides a mock function with given fields: ctx, zipCode, days
+func (_m *MockGetForecastByZipCodeUsecase) GetForecastByZipCode(ctx context.Context, zipCode model.ZipCode, days int) (*model.Forecast, *model.CustomError) {
+	ret := _m.Called(ctx, zipCode, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForecastByZipCode")
+	}
+
+	var r0 *model.Forecast
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode, int) (*model.Forecast, *model.CustomError)); ok {
+		return rf(ctx, zipCode, days)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode, int) *model.Forecast); ok {
+		r0 = rf(ctx, zipCode, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Forecast)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.ZipCode, int) *model.CustomError); ok {
+		r1 = rf(ctx, zipCode, days)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewMockGetForecastByZipCodeUsecase creates a new instance of MockGetForecastByZipCodeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetForecastByZipCodeUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetForecastByZipCodeUsecase {
+	mock := &MockGetForecastByZipCodeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
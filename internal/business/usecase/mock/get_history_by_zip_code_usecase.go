@@ -0,0 +1,63 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	model "github.com/Berchon/weather-cloud-run/internal/business/model"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockGetHistoryByZipCodeUsecase is an autogenerated mock type for the GetHistoryByZipCodeUsecase type
+type MockGetHistoryByZipCodeUsecase struct {
+	mock.Mock
+}
+
+// GetHistoryByZipCode provides a mock function with given fields: ctx, zipCode, date
+func (_m *MockGetHistoryByZipCodeUsecase) GetHistoryByZipCode(ctx context.Context, zipCode model.ZipCode, date time.Time) (*model.History, *model.CustomError) {
+	ret := _m.Called(ctx, zipCode, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistoryByZipCode")
+	}
+
+	var r0 *model.History
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode, time.Time) (*model.History, *model.CustomError)); ok {
+		return rf(ctx, zipCode, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode, time.Time) *model.History); ok {
+		r0 = rf(ctx, zipCode, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.History)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.ZipCode, time.Time) *model.CustomError); ok {
+		r1 = rf(ctx, zipCode, date)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewMockGetHistoryByZipCodeUsecase creates a new instance of MockGetHistoryByZipCodeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetHistoryByZipCodeUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetHistoryByZipCodeUsecase {
+	mock := &MockGetHistoryByZipCodeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
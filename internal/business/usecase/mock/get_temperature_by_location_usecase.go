@@ -0,0 +1,125 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	model "github.com/Berchon/weather-cloud-run/internal/business/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetTemperatureByLocationUsecase is an autogenerated mock type for the GetTemperatureByLocationUsecase type
+type MockGetTemperatureByLocationUsecase struct {
+	mock.Mock
+}
+
+// GetTemperatureByCity provides a mock function with given fields: ctx, city
+func (_m *MockGetTemperatureByLocationUsecase) GetTemperatureByCity(ctx context.Context, city model.City) (*model.Temperature, *model.CustomError) {
+	ret := _m.Called(ctx, city)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemperatureByCity")
+	}
+
+	var r0 *model.Temperature
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.City) (*model.Temperature, *model.CustomError)); ok {
+		return rf(ctx, city)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.City) *model.Temperature); ok {
+		r0 = rf(ctx, city)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Temperature)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.City) *model.CustomError); ok {
+		r1 = rf(ctx, city)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTemperatureByCoordinates provides a mock function with given fields: ctx, coordinates
+func (_m *MockGetTemperatureByLocationUsecase) GetTemperatureByCoordinates(ctx context.Context, coordinates model.Coordinates) (*model.Temperature, *model.CustomError) {
+	ret := _m.Called(ctx, coordinates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemperatureByCoordinates")
+	}
+
+	var r0 *model.Temperature
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.Coordinates) (*model.Temperature, *model.CustomError)); ok {
+		return rf(ctx, coordinates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Coordinates) *model.Temperature); ok {
+		r0 = rf(ctx, coordinates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Temperature)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Coordinates) *model.CustomError); ok {
+		r1 = rf(ctx, coordinates)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetTemperatureByIBGECode provides a mock function with given fields: ctx, ibgeCode
+func (_m *MockGetTemperatureByLocationUsecase) GetTemperatureByIBGECode(ctx context.Context, ibgeCode model.IBGECode) (*model.Temperature, *model.CustomError) {
+	ret := _m.Called(ctx, ibgeCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemperatureByIBGECode")
+	}
+
+	var r0 *model.Temperature
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.IBGECode) (*model.Temperature, *model.CustomError)); ok {
+		return rf(ctx, ibgeCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.IBGECode) *model.Temperature); ok {
+		r0 = rf(ctx, ibgeCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Temperature)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.IBGECode) *model.CustomError); ok {
+		r1 = rf(ctx, ibgeCode)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewMockGetTemperatureByLocationUsecase creates a new instance of MockGetTemperatureByLocationUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetTemperatureByLocationUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetTemperatureByLocationUsecase {
+	mock := &MockGetTemperatureByLocationUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,24 +14,56 @@ type MockGetTemperatureByZipCodeUsecase struct {
 	mock.Mock
 }
 
+// GetDetailedTemperatureByZipCode provides a mock function with given fields: ctx, zipCode, includes
+func (_m *MockGetTemperatureByZipCodeUsecase) GetDetailedTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode, includes model.TemperatureIncludes) (*model.DetailedTemperature, *model.CustomError) {
+	ret := _m.Called(ctx, zipCode, includes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDetailedTemperatureByZipCode")
+	}
+
+	var r0 *model.DetailedTemperature
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode, model.TemperatureIncludes) (*model.DetailedTemperature, *model.CustomError)); ok {
+		return rf(ctx, zipCode, includes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode, model.TemperatureIncludes) *model.DetailedTemperature); ok {
+		r0 = rf(ctx, zipCode, includes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.DetailedTemperature)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.ZipCode, model.TemperatureIncludes) *model.CustomError); ok {
+		r1 = rf(ctx, zipCode, includes)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetTemperatureByZipCode provides a mock function with given fields: ctx, zipCode
-func (_m *MockGetTemperatureByZipCodeUsecase) GetTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode) (*map[string]float64, *model.CustomError) {
+func (_m *MockGetTemperatureByZipCodeUsecase) GetTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Temperature, *model.CustomError) {
 	ret := _m.Called(ctx, zipCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTemperatureByZipCode")
 	}
 
-	var r0 *map[string]float64
+	var r0 *model.Temperature
 	var r1 *model.CustomError
-	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) (*map[string]float64, *model.CustomError)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) (*model.Temperature, *model.CustomError)); ok {
 		return rf(ctx, zipCode)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) *map[string]float64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) *model.Temperature); ok {
 		r0 = rf(ctx, zipCode)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*map[string]float64)
+			r0 = ret.Get(0).(*model.Temperature)
 		}
 	}
 
@@ -0,0 +1,49 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	model "github.com/Berchon/weather-cloud-run/internal/business/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetTemperaturesByZipCodesUsecase is an autogenerated mock type for the GetTemperaturesByZipCodesUsecase type
+type MockGetTemperaturesByZipCodesUsecase struct {
+	mock.Mock
+}
+
+// GetTemperaturesByZipCodes provides a mock function with given fields: ctx, zipCodes
+func (_m *MockGetTemperaturesByZipCodesUsecase) GetTemperaturesByZipCodes(ctx context.Context, zipCodes []string) []model.TemperatureResult {
+	ret := _m.Called(ctx, zipCodes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTemperaturesByZipCodes")
+	}
+
+	var r0 []model.TemperatureResult
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.TemperatureResult); ok {
+		r0 = rf(ctx, zipCodes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TemperatureResult)
+		}
+	}
+
+	return r0
+}
+
+// NewMockGetTemperaturesByZipCodesUsecase creates a new instance of MockGetTemperaturesByZipCodesUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetTemperaturesByZipCodesUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetTemperaturesByZipCodesUsecase {
+	mock := &MockGetTemperaturesByZipCodesUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
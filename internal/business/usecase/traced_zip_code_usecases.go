@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracedGetTemperatureByZipCodeUsecase struct {
+	next   GetTemperatureByZipCodeUsecase
+	tracer trace.Tracer
+}
+
+// NewTracedGetTemperatureByZipCodeUsecase wraps every call to next in a span
+// named after the method, so a slow request shows which lookup took the time.
+func NewTracedGetTemperatureByZipCodeUsecase(next GetTemperatureByZipCodeUsecase, tracer trace.Tracer) GetTemperatureByZipCodeUsecase {
+	return &tracedGetTemperatureByZipCodeUsecase{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (uc *tracedGetTemperatureByZipCodeUsecase) GetTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Temperature, *model.CustomError) {
+	ctx, span := startZipCodeSpan(ctx, uc.tracer, "GetTemperatureByZipCode", zipCode)
+	result, err := uc.next.GetTemperatureByZipCode(ctx, zipCode)
+	endSpan(span, err)
+	return result, err
+}
+
+func (uc *tracedGetTemperatureByZipCodeUsecase) GetDetailedTemperatureByZipCode(ctx context.Context, zipCode model.ZipCode, includes model.TemperatureIncludes) (*model.DetailedTemperature, *model.CustomError) {
+	ctx, span := startZipCodeSpan(ctx, uc.tracer, "GetDetailedTemperatureByZipCode", zipCode)
+	result, err := uc.next.GetDetailedTemperatureByZipCode(ctx, zipCode, includes)
+	endSpan(span, err)
+	return result, err
+}
+
+type tracedGetForecastByZipCodeUsecase struct {
+	next   GetForecastByZipCodeUsecase
+	tracer trace.Tracer
+}
+
+func NewTracedGetForecastByZipCodeUsecase(next GetForecastByZipCodeUsecase, tracer trace.Tracer) GetForecastByZipCodeUsecase {
+	return &tracedGetForecastByZipCodeUsecase{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (uc *tracedGetForecastByZipCodeUsecase) GetForecastByZipCode(ctx context.Context, zipCode model.ZipCode, days int) (*model.Forecast, *model.CustomError) {
+	ctx, span := startZipCodeSpan(ctx, uc.tracer, "GetForecastByZipCode", zipCode)
+	result, err := uc.next.GetForecastByZipCode(ctx, zipCode, days)
+	endSpan(span, err)
+	return result, err
+}
+
+type tracedGetConditionsByZipCodeUsecase struct {
+	next   GetConditionsByZipCodeUsecase
+	tracer trace.Tracer
+}
+
+func NewTracedGetConditionsByZipCodeUsecase(next GetConditionsByZipCodeUsecase, tracer trace.Tracer) GetConditionsByZipCodeUsecase {
+	return &tracedGetConditionsByZipCodeUsecase{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (uc *tracedGetConditionsByZipCodeUsecase) GetConditionsByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Conditions, *model.CustomError) {
+	ctx, span := startZipCodeSpan(ctx, uc.tracer, "GetConditionsByZipCode", zipCode)
+	result, err := uc.next.GetConditionsByZipCode(ctx, zipCode)
+	endSpan(span, err)
+	return result, err
+}
+
+type tracedGetHistoryByZipCodeUsecase struct {
+	next   GetHistoryByZipCodeUsecase
+	tracer trace.Tracer
+}
+
+func NewTracedGetHistoryByZipCodeUsecase(next GetHistoryByZipCodeUsecase, tracer trace.Tracer) GetHistoryByZipCodeUsecase {
+	return &tracedGetHistoryByZipCodeUsecase{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (uc *tracedGetHistoryByZipCodeUsecase) GetHistoryByZipCode(ctx context.Context, zipCode model.ZipCode, date time.Time) (*model.History, *model.CustomError) {
+	ctx, span := startZipCodeSpan(ctx, uc.tracer, "GetHistoryByZipCode", zipCode)
+	result, err := uc.next.GetHistoryByZipCode(ctx, zipCode, date)
+	endSpan(span, err)
+	return result, err
+}
+
+type tracedGetAirQualityByZipCodeUsecase struct {
+	next   GetAirQualityByZipCodeUsecase
+	tracer trace.Tracer
+}
+
+func NewTracedGetAirQualityByZipCodeUsecase(next GetAirQualityByZipCodeUsecase, tracer trace.Tracer) GetAirQualityByZipCodeUsecase {
+	return &tracedGetAirQualityByZipCodeUsecase{
+		next:   next,
+		tracer: tracer,
+	}
+}
+
+func (uc *tracedGetAirQualityByZipCodeUsecase) GetAirQualityByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.AirQualityReport, *model.CustomError) {
+	ctx, span := startZipCodeSpan(ctx, uc.tracer, "GetAirQualityByZipCode", zipCode)
+	result, err := uc.next.GetAirQualityByZipCode(ctx, zipCode)
+	endSpan(span, err)
+	return result, err
+}
+
+func startZipCodeSpan(ctx context.Context, tracer trace.Tracer, name string, zipCode model.ZipCode) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attribute.String("zip_code", string(zipCode))))
+}
+
+// endSpan records the error on the span; only server side errors mark it failed,
+// an unknown zip code is a normal answer.
+func endSpan(span trace.Span, err *model.CustomError) {
+	if err != nil {
+		span.SetAttributes(attribute.Int("error.status_code", err.StatusCode), attribute.String("error.code", string(err.Code)))
+		if err.StatusCode >= 500 {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracedGetTemperatureByZipCode(t *testing.T) {
+	zip := model.ZipCode("12345678")
+	exporter := tracetest.NewInMemoryExporter()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)).Tracer("test")
+
+	t.Run("should pass the span context down to the services", func(t *testing.T) {
+		exporter.Reset()
+		address := &model.Address{ZipCode: "12345678", City: "Porto Alegre", State: "RS"}
+		var serviceSpan trace.SpanContext
+		viaCep := serviceMock.NewMockViaCepService(t)
+		viaCep.On("GetAddressByZipCode", mock.Anything, zip).Run(func(args mock.Arguments) {
+			serviceSpan = trace.SpanContextFromContext(args.Get(0).(context.Context))
+		}).Return(address, nil).Once()
+		weather := serviceMock.NewMockWeatherService(t)
+		weather.On("GetWeatherByCity", mock.Anything, address.ToCity()).Return(&model.Weather{Celsius: 20}, nil).Once()
+		uc := NewTracedGetTemperatureByZipCodeUsecase(NewGetTemperatureByZipCodeUsecase(viaCep, weather, model.DefaultTemperatureOptions(), nil), tracer)
+
+		result, err := uc.GetTemperatureByZipCode(context.Background(), zip)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 20.0, result.Celsius)
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "GetTemperatureByZipCode", spans[0].Name)
+		assert.Equal(t, spans[0].SpanContext, serviceSpan)
+		assert.Contains(t, spans[0].Attributes, attribute.String("zip_code", "12345678"))
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("should mark the span failed only on server errors", func(t *testing.T) {
+		exporter.Reset()
+		viaCep := serviceMock.NewMockViaCepService(t)
+		viaCep.On("GetAddressByZipCode", mock.Anything, zip).Return(nil, model.NewZipCodeNotFoundError()).Once()
+		viaCep.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCodedError(model.ErrorCodeUpstreamUnavailable, http.StatusServiceUnavailable, "via cep is temporarily unavailable")).Once()
+		uc := NewTracedGetConditionsByZipCodeUsecase(NewGetConditionsByZipCodeUsecase(viaCep, nil, model.DefaultTemperatureOptions(), nil), tracer)
+
+		_, _ = uc.GetConditionsByZipCode(context.Background(), zip)
+		_, _ = uc.GetConditionsByZipCode(context.Background(), zip)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+		assert.Contains(t, spans[0].Attributes, attribute.Int("error.status_code", http.StatusNotFound))
+		assert.Equal(t, codes.Error, spans[1].Status.Code)
+		assert.Contains(t, spans[1].Attributes, attribute.String("error.code", "UPSTREAM_UNAVAILABLE"))
+	})
+}
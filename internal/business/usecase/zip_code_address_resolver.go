@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+// zipCodeAddressResolver is the zip code lookup every zip code usecase starts
+// with, logging its failures the same way for all of them.
+type zipCodeAddressResolver struct {
+	ViaCepService gateway.ViaCepService
+	Logger        *slog.Logger
+}
+
+// newZipCodeAddressResolver logs to logger, or nowhere when it is nil.
+func newZipCodeAddressResolver(viaCepService gateway.ViaCepService, logger *slog.Logger) zipCodeAddressResolver {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	return zipCodeAddressResolver{
+		ViaCepService: viaCepService,
+		Logger:        logger,
+	}
+}
+
+func (r zipCodeAddressResolver) address(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError) {
+	address, err := r.ViaCepService.GetAddressByZipCode(ctx, zipCode)
+	if err != nil {
+		r.logError(ctx, "zip code lookup failed", err)
+		return nil, err
+	}
+
+	if address.City == "" {
+		err := model.NewCustomError(http.StatusInternalServerError, "city field is empty in response from via cep service")
+		r.logError(ctx, "zip code lookup failed", err)
+		return nil, err
+	}
+
+	return address, nil
+}
+
+// logError logs server side errors as errors; client side ones, such as an
+// unknown zip code, are expected and only logged at debug level.
+func (r zipCodeAddressResolver) logError(ctx context.Context, msg string, err *model.CustomError) {
+	level := slog.LevelDebug
+	if err.StatusCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	r.Logger.LogAttrs(ctx, level, msg,
+		slog.Int("status_code", err.StatusCode),
+		slog.String("code", string(err.Code)),
+		slog.String("error", err.Error()))
+}
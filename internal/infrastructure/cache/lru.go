@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Stats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	Size   int    `json:"size"`
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// LRU is a size-bounded, concurrency-safe cache where every entry carries its
+// own TTL. When full, the least recently used entry is evicted.
+type LRU[K comparable, V any] struct {
+	mu     sync.Mutex
+	size   int
+	items  map[K]*list.Element
+	order  *list.List
+	now    func() time.Time
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func NewLRU[K comparable, V any](size int) *LRU[K, V] {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &LRU[K, V]{
+		size:  size,
+		items: make(map[K]*list.Element, size),
+		order: list.New(),
+		now:   time.Now,
+	}
+}
+
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+
+	item := element.Value.(*entry[K, V])
+	if !c.now().Before(item.expiresAt) {
+		c.removeElement(element)
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	c.hits.Add(1)
+	return item.value, true
+}
+
+// Peek returns the unexpired value of key like Get, but counts neither a hit
+// nor a miss and leaves the eviction order alone.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		item := element.Value.(*entry[K, V])
+		if c.now().Before(item.expiresAt) {
+			return item.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *LRU[K, V]) Set(key K, value V, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if element, ok := c.items[key]; ok {
+		item := element.Value.(*entry[K, V])
+		item.value = value
+		item.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) Stats() Stats {
+	return Stats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Size:   c.Len(),
+	}
+}
+
+func (c *LRU[K, V]) removeElement(element *list.Element) {
+	item := c.order.Remove(element).(*entry[K, V])
+	delete(c.items, item.key)
+}
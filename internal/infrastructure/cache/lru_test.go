@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestLRU(size int) (*LRU[string, int], *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c := NewLRU[string, int](size)
+	c.now = clock.Now
+	return c, clock
+}
+
+func TestLRU(t *testing.T) {
+	t.Run("should return stored value before ttl expires", func(t *testing.T) {
+		c, clock := newTestLRU(2)
+		c.Set("a", 1, time.Minute)
+
+		clock.Advance(59 * time.Second)
+		value, ok := c.Get("a")
+
+		assert.True(t, ok)
+		assert.Equal(t, 1, value)
+	})
+
+	t.Run("should miss and evict entry after ttl expires", func(t *testing.T) {
+		c, clock := newTestLRU(2)
+		c.Set("a", 1, time.Minute)
+
+		clock.Advance(time.Minute)
+		_, ok := c.Get("a")
+
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("should evict least recently used entry when full", func(t *testing.T) {
+		c, _ := newTestLRU(2)
+		c.Set("a", 1, time.Minute)
+		c.Set("b", 2, time.Minute)
+		c.Get("a")
+		c.Set("c", 3, time.Minute)
+
+		_, okA := c.Get("a")
+		_, okB := c.Get("b")
+		_, okC := c.Get("c")
+
+		assert.True(t, okA)
+		assert.False(t, okB)
+		assert.True(t, okC)
+		assert.Equal(t, 2, c.Len())
+	})
+
+	t.Run("should overwrite value and ttl when key already exists", func(t *testing.T) {
+		c, clock := newTestLRU(2)
+		c.Set("a", 1, time.Second)
+		c.Set("a", 2, time.Minute)
+
+		clock.Advance(30 * time.Second)
+		value, ok := c.Get("a")
+
+		assert.True(t, ok)
+		assert.Equal(t, 2, value)
+		assert.Equal(t, 1, c.Len())
+	})
+
+	t.Run("should not store entries with non positive ttl", func(t *testing.T) {
+		c, _ := newTestLRU(2)
+		c.Set("a", 1, 0)
+
+		_, ok := c.Get("a")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("should count hits and misses", func(t *testing.T) {
+		c, _ := newTestLRU(2)
+		c.Set("a", 1, time.Minute)
+		c.Get("a")
+		c.Get("a")
+		c.Get("b")
+
+		assert.Equal(t, Stats{Hits: 2, Misses: 1, Size: 1}, c.Stats())
+	})
+
+	t.Run("should peek without counting or refreshing", func(t *testing.T) {
+		c, clock := newTestLRU(2)
+		c.Set("a", 1, time.Minute)
+		c.Set("b", 2, time.Minute)
+
+		value, ok := c.Peek("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, value)
+		_, ok = c.Peek("missing")
+		assert.False(t, ok)
+		assert.Equal(t, Stats{Size: 2}, c.Stats())
+
+		c.Set("c", 3, time.Minute)
+		_, ok = c.Peek("a")
+		assert.False(t, ok, "peek must not save a from eviction")
+
+		clock.Advance(time.Minute)
+		_, ok = c.Peek("b")
+		assert.False(t, ok)
+	})
+
+	t.Run("should use size one when size is not positive", func(t *testing.T) {
+		c, _ := newTestLRU(0)
+		c.Set("a", 1, time.Minute)
+		c.Set("b", 2, time.Minute)
+
+		assert.Equal(t, 1, c.Len())
+	})
+}
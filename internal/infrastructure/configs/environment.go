@@ -1,101 +1,328 @@
 package configs
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
-type cfg struct {
+// Config is the service configuration. It is a plain value: once loaded and
+// validated it is never modified, so it can be shared freely between
+// goroutines and handed to the components that need it. A reload builds a new
+// Config, see Store.
+type Config struct {
 	WebServerPort  string `mapstructure:"WEB_SERVER_PORT"`
 	ViaCepBaseUrl  string `mapstructure:"VIACEP_BASE_URL"`
 	ViaCepPath     string `mapstructure:"VIACEP_PATH"`
 	WeatherBaseUrl string `mapstructure:"WEATHER_BASE_URL"`
 	WeatherPath    string `mapstructure:"WEATHER_PATH"`
 	WeatherAPIKey  string `mapstructure:"WEATHER_API_KEY"`
-}
 
-var (
-	config   *cfg
-	lastPath string
-)
+	// WeatherAPIKeyFile is the path of a mounted secret holding the
+	// WeatherAPI key; when set, Load reads the key from it.
+	WeatherAPIKeyFile string `mapstructure:"WEATHER_API_KEY_FILE"`
 
-func LoadConfig(path string) error {
-	lastPath = path
+	BrasilApiBaseUrl string `mapstructure:"BRASILAPI_BASE_URL"`
+	BrasilApiPath    string `mapstructure:"BRASILAPI_PATH"`
+	OpenCepBaseUrl   string `mapstructure:"OPENCEP_BASE_URL"`
+	OpenCepPath      string `mapstructure:"OPENCEP_PATH"`
 
-	viper.SetDefault("WEB_SERVER_PORT", "8080")
-	viper.SetDefault("VIACEP_BASE_URL", "https://viacep.com.br")
-	viper.SetDefault("VIACEP_PATH", "/ws/%s/json")
-	viper.SetDefault("WEATHER_BASE_URL", "https://api.weatherapi.com")
-	viper.SetDefault("WEATHER_PATH", "/v1/current.json")
-	viper.SetDefault("WEATHER_API_KEY", "")
+	// ZipCodeProviders is the comma separated list of zip code providers, in
+	// the order they must be tried. See ZipCodeProviderNames.
+	ZipCodeProviders   string `mapstructure:"ZIPCODE_PROVIDERS"`
+	ZipCodeDatasetPath string `mapstructure:"ZIPCODE_DATASET_PATH"`
 
-	viper.SetConfigFile(fmt.Sprintf("%s/.env", path))
-	viper.AutomaticEnv()
+	// WeatherProvider is the comma separated list of weather providers, in
+	// failover order. See WeatherProviderNames.
+	WeatherProvider           string `mapstructure:"WEATHER_PROVIDER"`
+	OpenMeteoGeocodingBaseUrl string `mapstructure:"OPEN_METEO_GEOCODING_BASE_URL"`
+	OpenMeteoGeocodingPath    string `mapstructure:"OPEN_METEO_GEOCODING_PATH"`
+	OpenMeteoBaseUrl          string `mapstructure:"OPEN_METEO_BASE_URL"`
+	OpenMeteoPath             string `mapstructure:"OPEN_METEO_PATH"`
+	OpenWeatherMapBaseUrl     string `mapstructure:"OPENWEATHERMAP_BASE_URL"`
+	OpenWeatherMapPath        string `mapstructure:"OPENWEATHERMAP_PATH"`
+	OpenWeatherMapAPIKey      string `mapstructure:"OPENWEATHERMAP_API_KEY"`
 
-	if err := viper.ReadInConfig(); err != nil {
-		log.Println("Warning: is not possible to read .env file, using defaults values from env:", err)
-	}
+	ServerReadTimeout     time.Duration `mapstructure:"SERVER_READ_TIMEOUT"`
+	ServerWriteTimeout    time.Duration `mapstructure:"SERVER_WRITE_TIMEOUT"`
+	ServerIdleTimeout     time.Duration `mapstructure:"SERVER_IDLE_TIMEOUT"`
+	ServerShutdownTimeout time.Duration `mapstructure:"SERVER_SHUTDOWN_TIMEOUT"`
 
-	var c cfg
-	if err := viper.Unmarshal(&c); err != nil {
-		return fmt.Errorf("Error to unmarshal config: %w", err)
-	}
+	// RequestTimeout is the budget of a request, retries and failover
+	// included. It must be below SERVER_WRITE_TIMEOUT so clients get a 504
+	// instead of a reset connection.
+	RequestTimeout time.Duration `mapstructure:"REQUEST_TIMEOUT"`
 
-	config = &c
-	return nil
-}
+	ViaCepCacheSize        int           `mapstructure:"VIACEP_CACHE_SIZE"`
+	ViaCepCacheTTL         time.Duration `mapstructure:"VIACEP_CACHE_TTL"`
+	ViaCepCacheNotFoundTTL time.Duration `mapstructure:"VIACEP_CACHE_NOT_FOUND_TTL"`
 
-func RefreshConfig() error {
-	viper.Reset()
-	return LoadConfig(lastPath)
-}
+	WeatherCacheSize int           `mapstructure:"WEATHER_CACHE_SIZE"`
+	WeatherCacheTTL  time.Duration `mapstructure:"WEATHER_CACHE_TTL"`
 
-func GetWebServerPort() string {
-	return config.WebServerPort
-}
+	ViaCepRetryMaxAttempts     int           `mapstructure:"VIACEP_RETRY_MAX_ATTEMPTS"`
+	ViaCepRetryInitialBackoff  time.Duration `mapstructure:"VIACEP_RETRY_INITIAL_BACKOFF"`
+	ViaCepRetryMaxBackoff      time.Duration `mapstructure:"VIACEP_RETRY_MAX_BACKOFF"`
+	WeatherRetryMaxAttempts    int           `mapstructure:"WEATHER_RETRY_MAX_ATTEMPTS"`
+	WeatherRetryInitialBackoff time.Duration `mapstructure:"WEATHER_RETRY_INITIAL_BACKOFF"`
+	WeatherRetryMaxBackoff     time.Duration `mapstructure:"WEATHER_RETRY_MAX_BACKOFF"`
 
-func SetWebServerPort(port string) {
-	config.WebServerPort = port
-}
+	CircuitBreakerConsecutiveFailures int           `mapstructure:"CIRCUIT_BREAKER_CONSECUTIVE_FAILURES"`
+	CircuitBreakerFailureRatio        float64       `mapstructure:"CIRCUIT_BREAKER_FAILURE_RATIO"`
+	CircuitBreakerMinRequests         int           `mapstructure:"CIRCUIT_BREAKER_MIN_REQUESTS"`
+	CircuitBreakerInterval            time.Duration `mapstructure:"CIRCUIT_BREAKER_INTERVAL"`
+	CircuitBreakerCoolDown            time.Duration `mapstructure:"CIRCUIT_BREAKER_COOL_DOWN"`
+	CircuitBreakerHalfOpenMaxRequests int           `mapstructure:"CIRCUIT_BREAKER_HALF_OPEN_MAX_REQUESTS"`
 
-func GetViaCepBaseUrl() string {
-	return config.ViaCepBaseUrl
-}
+	// TemperaturePreciseKelvin reports whether Kelvin uses the 273.15 offset
+	// instead of the 273 the service contract specifies.
+	TemperaturePreciseKelvin bool `mapstructure:"TEMPERATURE_PRECISE_KELVIN"`
+	TemperaturePrecision     uint `mapstructure:"TEMPERATURE_PRECISION"`
 
-func SetViaCepBaseUrl(url string) {
-	config.ViaCepBaseUrl = url
-}
+	// BatchMaxZipCodes is the largest list POST /temperatures accepts.
+	BatchMaxZipCodes int `mapstructure:"BATCH_MAX_ZIP_CODES"`
 
-func GetViaCepPath() string {
-	return config.ViaCepPath
+	// BatchWorkers bounds how many zip codes of a batch are resolved at once.
+	BatchWorkers int `mapstructure:"BATCH_WORKERS"`
+
+	IbgeBaseUrl string `mapstructure:"IBGE_BASE_URL"`
+	IbgePath    string `mapstructure:"IBGE_PATH"`
+
+	WeatherForecastPath string `mapstructure:"WEATHER_FORECAST_PATH"`
+
+	// ForecastMaxDays is the largest ?days= accepted by /forecast; the
+	// WeatherAPI free plan answers at most 3 days.
+	ForecastMaxDays int `mapstructure:"FORECAST_MAX_DAYS"`
+
+	WeatherHistoryPath string `mapstructure:"WEATHER_HISTORY_PATH"`
+
+	// HistoryMaxDays is how far back /history looks; the WeatherAPI free plan
+	// keeps 7 days.
+	HistoryMaxDays int `mapstructure:"HISTORY_MAX_DAYS"`
+
+	// TracingExporter is where spans go: otlp, stdout or none. With none,
+	// trace context is still propagated to upstreams but nothing is exported.
+	TracingExporter string `mapstructure:"TRACING_EXPORTER"`
+
+	// TracingOTLPEndpoint is the host:port of the OTLP/HTTP collector. Empty
+	// leaves it to the standard OTEL_EXPORTER_OTLP_* variables.
+	TracingOTLPEndpoint string `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingServiceName  string `mapstructure:"TRACING_SERVICE_NAME"`
+
+	// LogLevel is the minimum level logged: debug, info, warn or error.
+	LogLevel string `mapstructure:"LOG_LEVEL"`
+
+	// LogFormat is json, one object per line, or text for reading locally.
+	LogFormat string `mapstructure:"LOG_FORMAT"`
+
+	// LogCloudLogging names the JSON fields the way Cloud Logging reads them:
+	// severity, message and timestamp instead of level, msg and time.
+	LogCloudLogging bool `mapstructure:"LOG_CLOUD_LOGGING"`
 }
 
-func SetViaCepPath(path string) {
-	config.ViaCepPath = path
+// Load reads the configuration from the .env file in path and the
+// environment, which wins over the file, and validates it. It touches no
+// package state, so tests can load as many configurations as they need.
+// warnings are problems Load worked around, such as an unreadable .env file;
+// the caller logs them once its logger is configured.
+func Load(path string) (c Config, warnings []error, err error) {
+	return load(path, os.LookupEnv)
 }
 
-func GetWeatherBaseUrl() string {
-	return config.WeatherBaseUrl
+// load is Load reading the environment through lookupEnv, so tests can give
+// each load its own environment instead of changing the process one.
+func load(path string, lookupEnv func(key string) (string, bool)) (c Config, warnings []error, err error) {
+	v := viper.New()
+
+	v.SetDefault("WEB_SERVER_PORT", "8080")
+	v.SetDefault("VIACEP_BASE_URL", "https://viacep.com.br")
+	v.SetDefault("VIACEP_PATH", "/ws/%s/json")
+	v.SetDefault("WEATHER_BASE_URL", "https://api.weatherapi.com")
+	v.SetDefault("WEATHER_PATH", "/v1/current.json")
+	v.SetDefault("WEATHER_API_KEY", "")
+	v.SetDefault("WEATHER_API_KEY_FILE", "")
+	v.SetDefault("BRASILAPI_BASE_URL", "https://brasilapi.com.br")
+	v.SetDefault("BRASILAPI_PATH", "/api/cep/v2/%s")
+	v.SetDefault("OPENCEP_BASE_URL", "https://opencep.com")
+	v.SetDefault("OPENCEP_PATH", "/v1/%s")
+	v.SetDefault("ZIPCODE_PROVIDERS", "viacep,brasilapi,opencep,local")
+	v.SetDefault("ZIPCODE_DATASET_PATH", "")
+	v.SetDefault("WEATHER_PROVIDER", "weatherapi")
+	v.SetDefault("OPEN_METEO_GEOCODING_BASE_URL", "https://geocoding-api.open-meteo.com")
+	v.SetDefault("OPEN_METEO_GEOCODING_PATH", "/v1/search")
+	v.SetDefault("OPEN_METEO_BASE_URL", "https://api.open-meteo.com")
+	v.SetDefault("OPEN_METEO_PATH", "/v1/forecast")
+	v.SetDefault("OPENWEATHERMAP_BASE_URL", "https://api.openweathermap.org")
+	v.SetDefault("OPENWEATHERMAP_PATH", "/data/2.5/weather")
+	v.SetDefault("OPENWEATHERMAP_API_KEY", "")
+	v.SetDefault("SERVER_READ_TIMEOUT", "5s")
+	v.SetDefault("SERVER_WRITE_TIMEOUT", "10s")
+	v.SetDefault("SERVER_IDLE_TIMEOUT", "60s")
+	v.SetDefault("SERVER_SHUTDOWN_TIMEOUT", "10s")
+	v.SetDefault("REQUEST_TIMEOUT", "8s")
+	v.SetDefault("VIACEP_CACHE_SIZE", 1000)
+	v.SetDefault("VIACEP_CACHE_TTL", "24h")
+	v.SetDefault("VIACEP_CACHE_NOT_FOUND_TTL", "10m")
+	v.SetDefault("WEATHER_CACHE_SIZE", 500)
+	v.SetDefault("WEATHER_CACHE_TTL", "1m")
+	v.SetDefault("VIACEP_RETRY_MAX_ATTEMPTS", 3)
+	v.SetDefault("VIACEP_RETRY_INITIAL_BACKOFF", "100ms")
+	v.SetDefault("VIACEP_RETRY_MAX_BACKOFF", "1s")
+	v.SetDefault("WEATHER_RETRY_MAX_ATTEMPTS", 3)
+	v.SetDefault("WEATHER_RETRY_INITIAL_BACKOFF", "100ms")
+	v.SetDefault("WEATHER_RETRY_MAX_BACKOFF", "1s")
+	v.SetDefault("CIRCUIT_BREAKER_CONSECUTIVE_FAILURES", 5)
+	v.SetDefault("CIRCUIT_BREAKER_FAILURE_RATIO", 0.5)
+	v.SetDefault("CIRCUIT_BREAKER_MIN_REQUESTS", 10)
+	v.SetDefault("CIRCUIT_BREAKER_INTERVAL", "1m")
+	v.SetDefault("CIRCUIT_BREAKER_COOL_DOWN", "30s")
+	v.SetDefault("CIRCUIT_BREAKER_HALF_OPEN_MAX_REQUESTS", 1)
+	v.SetDefault("TEMPERATURE_PRECISE_KELVIN", false)
+	v.SetDefault("TEMPERATURE_PRECISION", 1)
+	v.SetDefault("BATCH_MAX_ZIP_CODES", 100)
+	v.SetDefault("BATCH_WORKERS", 10)
+	v.SetDefault("IBGE_BASE_URL", "https://servicodados.ibge.gov.br")
+	v.SetDefault("IBGE_PATH", "/api/v1/localidades/municipios/%s")
+	v.SetDefault("WEATHER_FORECAST_PATH", "/v1/forecast.json")
+	v.SetDefault("FORECAST_MAX_DAYS", 3)
+	v.SetDefault("WEATHER_HISTORY_PATH", "/v1/history.json")
+	v.SetDefault("HISTORY_MAX_DAYS", 7)
+	v.SetDefault("TRACING_EXPORTER", "none")
+	v.SetDefault("TRACING_OTLP_ENDPOINT", "")
+	v.SetDefault("TRACING_SERVICE_NAME", "weather-cloud-run")
+	v.SetDefault("LOG_LEVEL", "info")
+	v.SetDefault("LOG_FORMAT", "json")
+	v.SetDefault("LOG_CLOUD_LOGGING", true)
+
+	v.SetConfigFile(fmt.Sprintf("%s/.env", path))
+	if err := v.ReadInConfig(); err != nil {
+		warnings = append(warnings, fmt.Errorf("is not possible to read .env file, using defaults values from env: %w", err))
+	}
+
+	// Every key has a default, so AllKeys lists them all. Empty variables are
+	// ignored, as viper's AutomaticEnv does.
+	for _, key := range v.AllKeys() {
+		if value, ok := lookupEnv(strings.ToUpper(key)); ok && value != "" {
+			v.Set(key, value)
+		}
+	}
+
+	if err := v.Unmarshal(&c); err != nil {
+		return Config{}, warnings, fmt.Errorf("Error to unmarshal config: %w", err)
+	}
+	if c.WeatherAPIKeyFile != "" {
+		key, err := readSecretFile(c.WeatherAPIKeyFile)
+		if err != nil {
+			return Config{}, warnings, err
+		}
+		c.WeatherAPIKey = key
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, warnings, err
+	}
+	return c, warnings, nil
 }
 
-func SetWeatherBaseUrl(url string) {
-	config.WeatherBaseUrl = url
+// Validate reports every setting the service can not run with.
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.WebServerPort == "" {
+		errs = append(errs, errors.New("WEB_SERVER_PORT must be set"))
+	}
+	for _, setting := range []struct {
+		name  string
+		value string
+	}{
+		{"VIACEP_BASE_URL", c.ViaCepBaseUrl},
+		{"WEATHER_BASE_URL", c.WeatherBaseUrl},
+		{"BRASILAPI_BASE_URL", c.BrasilApiBaseUrl},
+		{"OPENCEP_BASE_URL", c.OpenCepBaseUrl},
+		{"OPEN_METEO_GEOCODING_BASE_URL", c.OpenMeteoGeocodingBaseUrl},
+		{"OPEN_METEO_BASE_URL", c.OpenMeteoBaseUrl},
+		{"OPENWEATHERMAP_BASE_URL", c.OpenWeatherMapBaseUrl},
+		{"IBGE_BASE_URL", c.IbgeBaseUrl},
+	} {
+		if parsed, err := url.Parse(setting.value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			errs = append(errs, fmt.Errorf("%s must be an absolute URL, got %q", setting.name, setting.value))
+		}
+	}
+	for _, setting := range []struct {
+		name  string
+		value int
+	}{
+		{"VIACEP_RETRY_MAX_ATTEMPTS", c.ViaCepRetryMaxAttempts},
+		{"WEATHER_RETRY_MAX_ATTEMPTS", c.WeatherRetryMaxAttempts},
+		{"BATCH_MAX_ZIP_CODES", c.BatchMaxZipCodes},
+		{"BATCH_WORKERS", c.BatchWorkers},
+		{"FORECAST_MAX_DAYS", c.ForecastMaxDays},
+		{"HISTORY_MAX_DAYS", c.HistoryMaxDays},
+	} {
+		if setting.value < 1 {
+			errs = append(errs, fmt.Errorf("%s must be at least 1, got %d", setting.name, setting.value))
+		}
+	}
+	if c.ViaCepCacheSize < 0 || c.WeatherCacheSize < 0 {
+		errs = append(errs, errors.New("cache sizes must not be negative"))
+	}
+	if c.CircuitBreakerFailureRatio < 0 || c.CircuitBreakerFailureRatio > 1 {
+		errs = append(errs, fmt.Errorf("CIRCUIT_BREAKER_FAILURE_RATIO must be between 0 and 1, got %v", c.CircuitBreakerFailureRatio))
+	}
+	if c.ServerShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("SERVER_SHUTDOWN_TIMEOUT must be positive"))
+	}
+	if c.RequestTimeout <= 0 {
+		errs = append(errs, errors.New("REQUEST_TIMEOUT must be positive"))
+	} else if c.ServerWriteTimeout > 0 && c.RequestTimeout >= c.ServerWriteTimeout {
+		errs = append(errs, fmt.Errorf("REQUEST_TIMEOUT must be below SERVER_WRITE_TIMEOUT, got %v and %v", c.RequestTimeout, c.ServerWriteTimeout))
+	}
+	for _, name := range c.WeatherProviderNames() {
+		if name == "weatherapi" && c.WeatherAPIKey == "" {
+			errs = append(errs, errors.New("WEATHER_API_KEY must be set when weatherapi is a weather provider"))
+		}
+		if name == "openweathermap" && c.OpenWeatherMapAPIKey == "" {
+			errs = append(errs, errors.New("OPENWEATHERMAP_API_KEY must be set when openweathermap is a weather provider"))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
 }
 
-func GetWeatherPath() string {
-	return config.WeatherPath
+// ZipCodeProviderNames returns the zip code providers in the order they must
+// be tried.
+func (c Config) ZipCodeProviderNames() []string {
+	return splitList(c.ZipCodeProviders)
 }
 
-func SetWeatherPath(path string) {
-	config.WeatherPath = path
+// WeatherProviderNames returns the weather providers in failover order.
+func (c Config) WeatherProviderNames() []string {
+	return splitList(c.WeatherProvider)
 }
 
-func GetWeatherAPIKey() string {
-	return config.WeatherAPIKey
+// readSecretFile reads a secret mounted as a file, such as a Cloud Run secret
+// volume, ignoring the trailing newline editors and kubectl leave behind.
+func readSecretFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("Error to read secret file %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(content)), nil
 }
 
-func SetWeatherAPIKey(key string) {
-	config.WeatherAPIKey = key
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -2,151 +2,346 @@ package configs
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func setEnvMock() {
-	os.Setenv("WEB_SERVER_PORT", "1234")
-	os.Setenv("VIACEP_BASE_URL", "http://mockviacep.com")
-	os.Setenv("VIACEP_PATH", "/mock/ws/%s/json")
-	os.Setenv("WEATHER_BASE_URL", "http://mockweather.com")
-	os.Setenv("WEATHER_PATH", "/mock/v1/current.json")
-	os.Setenv("WEATHER_API_KEY", "mock-key")
+// envMock is an environment setting every key, for load to read through
+// lookup instead of the process environment.
+func envMock() map[string]string {
+	return map[string]string{
+		"WEB_SERVER_PORT":                        "1234",
+		"VIACEP_BASE_URL":                        "http://mockviacep.com",
+		"VIACEP_PATH":                            "/mock/ws/%s/json",
+		"WEATHER_BASE_URL":                       "http://mockweather.com",
+		"WEATHER_PATH":                           "/mock/v1/current.json",
+		"WEATHER_API_KEY":                        "mock-key",
+		"BRASILAPI_BASE_URL":                     "http://mockbrasilapi.com",
+		"BRASILAPI_PATH":                         "/mock/cep/%s",
+		"OPENCEP_BASE_URL":                       "http://mockopencep.com",
+		"OPENCEP_PATH":                           "/mock/v1/%s",
+		"ZIPCODE_PROVIDERS":                      " OpenCep, viacep ,",
+		"ZIPCODE_DATASET_PATH":                   "/data/ceps.json",
+		"WEATHER_PROVIDER":                       "openmeteo, openweathermap",
+		"OPEN_METEO_GEOCODING_BASE_URL":          "http://mockgeocoding.com",
+		"OPEN_METEO_GEOCODING_PATH":              "/mock/search",
+		"OPEN_METEO_BASE_URL":                    "http://mockopenmeteo.com",
+		"OPEN_METEO_PATH":                        "/mock/forecast",
+		"OPENWEATHERMAP_BASE_URL":                "http://mockopenweathermap.com",
+		"OPENWEATHERMAP_PATH":                    "/mock/weather",
+		"OPENWEATHERMAP_API_KEY":                 "mock-owm-key",
+		"SERVER_READ_TIMEOUT":                    "1s",
+		"SERVER_WRITE_TIMEOUT":                   "2s",
+		"SERVER_IDLE_TIMEOUT":                    "3s",
+		"SERVER_SHUTDOWN_TIMEOUT":                "4s",
+		"REQUEST_TIMEOUT":                        "1500ms",
+		"VIACEP_CACHE_SIZE":                      "50",
+		"VIACEP_CACHE_TTL":                       "1h",
+		"VIACEP_CACHE_NOT_FOUND_TTL":             "1m",
+		"WEATHER_CACHE_SIZE":                     "20",
+		"WEATHER_CACHE_TTL":                      "30s",
+		"VIACEP_RETRY_MAX_ATTEMPTS":              "2",
+		"VIACEP_RETRY_INITIAL_BACKOFF":           "10ms",
+		"VIACEP_RETRY_MAX_BACKOFF":               "20ms",
+		"WEATHER_RETRY_MAX_ATTEMPTS":             "4",
+		"WEATHER_RETRY_INITIAL_BACKOFF":          "30ms",
+		"WEATHER_RETRY_MAX_BACKOFF":              "40ms",
+		"CIRCUIT_BREAKER_CONSECUTIVE_FAILURES":   "7",
+		"CIRCUIT_BREAKER_FAILURE_RATIO":          "0.8",
+		"CIRCUIT_BREAKER_MIN_REQUESTS":           "20",
+		"CIRCUIT_BREAKER_INTERVAL":               "2m",
+		"CIRCUIT_BREAKER_COOL_DOWN":              "15s",
+		"CIRCUIT_BREAKER_HALF_OPEN_MAX_REQUESTS": "2",
+		"TEMPERATURE_PRECISE_KELVIN":             "true",
+		"TEMPERATURE_PRECISION":                  "2",
+		"BATCH_MAX_ZIP_CODES":                    "50",
+		"BATCH_WORKERS":                          "4",
+		"IBGE_BASE_URL":                          "https://ibge.test",
+		"IBGE_PATH":                              "/municipios/%s",
+		"WEATHER_FORECAST_PATH":                  "/mock/forecast.json",
+		"FORECAST_MAX_DAYS":                      "7",
+		"WEATHER_HISTORY_PATH":                   "/mock/history.json",
+		"HISTORY_MAX_DAYS":                       "30",
+		"TRACING_EXPORTER":                       "stdout",
+		"TRACING_OTLP_ENDPOINT":                  "collector:4318",
+		"TRACING_SERVICE_NAME":                   "weather-test",
+		"LOG_LEVEL":                              "debug",
+		"LOG_FORMAT":                             "text",
+		"LOG_CLOUD_LOGGING":                      "false",
+		"WEATHER_API_KEY_FILE":                   "",
+	}
 }
 
-func unsetEnvMock() {
-	os.Unsetenv("WEB_SERVER_PORT")
-	os.Unsetenv("VIACEP_BASE_URL")
-	os.Unsetenv("VIACEP_PATH")
-	os.Unsetenv("WEATHER_BASE_URL")
-	os.Unsetenv("WEATHER_PATH")
-	os.Unsetenv("WEATHER_API_KEY")
+func lookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
 }
 
-func Test_LoadConfig(t *testing.T) {
+func Test_Load(t *testing.T) {
+	t.Parallel()
+
 	t.Run("When .env not found, should return default values", func(t *testing.T) {
-		unsetEnvMock()
-		err := LoadConfig(".")
+		t.Parallel()
+		c, warnings, err := load(".", lookup(map[string]string{"WEATHER_API_KEY": "key"}))
 		assert.NoError(t, err)
+		if assert.Len(t, warnings, 1) {
+			assert.ErrorContains(t, warnings[0], "is not possible to read .env file")
+		}
 
-		assert.Equal(t, "8080", GetWebServerPort())
-		assert.Equal(t, "https://viacep.com.br", GetViaCepBaseUrl())
-		assert.Equal(t, "/ws/%s/json", GetViaCepPath())
-		assert.Equal(t, "https://api.weatherapi.com", GetWeatherBaseUrl())
-		assert.Equal(t, "/v1/current.json", GetWeatherPath())
-		assert.Equal(t, "", GetWeatherAPIKey())
+		assert.Equal(t, "8080", c.WebServerPort)
+		assert.Equal(t, "https://viacep.com.br", c.ViaCepBaseUrl)
+		assert.Equal(t, "/ws/%s/json", c.ViaCepPath)
+		assert.Equal(t, "https://api.weatherapi.com", c.WeatherBaseUrl)
+		assert.Equal(t, "/v1/current.json", c.WeatherPath)
+		assert.Equal(t, "key", c.WeatherAPIKey)
+		assert.Equal(t, "https://brasilapi.com.br", c.BrasilApiBaseUrl)
+		assert.Equal(t, "/api/cep/v2/%s", c.BrasilApiPath)
+		assert.Equal(t, "https://opencep.com", c.OpenCepBaseUrl)
+		assert.Equal(t, "/v1/%s", c.OpenCepPath)
+		assert.Equal(t, []string{"viacep", "brasilapi", "opencep", "local"}, c.ZipCodeProviderNames())
+		assert.Equal(t, "", c.ZipCodeDatasetPath)
+		assert.Equal(t, []string{"weatherapi"}, c.WeatherProviderNames())
+		assert.Equal(t, "https://geocoding-api.open-meteo.com", c.OpenMeteoGeocodingBaseUrl)
+		assert.Equal(t, "/v1/search", c.OpenMeteoGeocodingPath)
+		assert.Equal(t, "https://api.open-meteo.com", c.OpenMeteoBaseUrl)
+		assert.Equal(t, "/v1/forecast", c.OpenMeteoPath)
+		assert.Equal(t, "https://api.openweathermap.org", c.OpenWeatherMapBaseUrl)
+		assert.Equal(t, "/data/2.5/weather", c.OpenWeatherMapPath)
+		assert.Equal(t, "", c.OpenWeatherMapAPIKey)
+		assert.Equal(t, 5*time.Second, c.ServerReadTimeout)
+		assert.Equal(t, 10*time.Second, c.ServerWriteTimeout)
+		assert.Equal(t, 60*time.Second, c.ServerIdleTimeout)
+		assert.Equal(t, 10*time.Second, c.ServerShutdownTimeout)
+		assert.Equal(t, 8*time.Second, c.RequestTimeout)
+		assert.Equal(t, 1000, c.ViaCepCacheSize)
+		assert.Equal(t, 24*time.Hour, c.ViaCepCacheTTL)
+		assert.Equal(t, 10*time.Minute, c.ViaCepCacheNotFoundTTL)
+		assert.Equal(t, 500, c.WeatherCacheSize)
+		assert.Equal(t, time.Minute, c.WeatherCacheTTL)
+		assert.Equal(t, 3, c.ViaCepRetryMaxAttempts)
+		assert.Equal(t, 100*time.Millisecond, c.ViaCepRetryInitialBackoff)
+		assert.Equal(t, time.Second, c.ViaCepRetryMaxBackoff)
+		assert.Equal(t, 3, c.WeatherRetryMaxAttempts)
+		assert.Equal(t, 100*time.Millisecond, c.WeatherRetryInitialBackoff)
+		assert.Equal(t, time.Second, c.WeatherRetryMaxBackoff)
+		assert.Equal(t, 5, c.CircuitBreakerConsecutiveFailures)
+		assert.Equal(t, 0.5, c.CircuitBreakerFailureRatio)
+		assert.Equal(t, 10, c.CircuitBreakerMinRequests)
+		assert.Equal(t, time.Minute, c.CircuitBreakerInterval)
+		assert.Equal(t, 30*time.Second, c.CircuitBreakerCoolDown)
+		assert.Equal(t, 1, c.CircuitBreakerHalfOpenMaxRequests)
+		assert.Equal(t, false, c.TemperaturePreciseKelvin)
+		assert.Equal(t, uint(1), c.TemperaturePrecision)
+		assert.Equal(t, 100, c.BatchMaxZipCodes)
+		assert.Equal(t, 10, c.BatchWorkers)
+		assert.Equal(t, "https://servicodados.ibge.gov.br", c.IbgeBaseUrl)
+		assert.Equal(t, "/api/v1/localidades/municipios/%s", c.IbgePath)
+		assert.Equal(t, "/v1/forecast.json", c.WeatherForecastPath)
+		assert.Equal(t, 3, c.ForecastMaxDays)
+		assert.Equal(t, "/v1/history.json", c.WeatherHistoryPath)
+		assert.Equal(t, 7, c.HistoryMaxDays)
+		assert.Equal(t, "none", c.TracingExporter)
+		assert.Equal(t, "", c.TracingOTLPEndpoint)
+		assert.Equal(t, "weather-cloud-run", c.TracingServiceName)
+		assert.Equal(t, "info", c.LogLevel)
+		assert.Equal(t, "json", c.LogFormat)
+		assert.Equal(t, true, c.LogCloudLogging)
+		assert.Equal(t, "", c.WeatherAPIKeyFile)
 	})
 
-	t.Run("When load .env successfully, should return file values", func(t *testing.T) {
-		setEnvMock()
-		defer unsetEnvMock()
-
-		err := LoadConfig(".")
+	t.Run("When the environment is set, should return its values", func(t *testing.T) {
+		t.Parallel()
+		c, _, err := load(".", lookup(envMock()))
 		assert.NoError(t, err)
 
-		assert.Equal(t, "1234", GetWebServerPort())
-		assert.Equal(t, "http://mockviacep.com", GetViaCepBaseUrl())
-		assert.Equal(t, "/mock/ws/%s/json", GetViaCepPath())
-		assert.Equal(t, "http://mockweather.com", GetWeatherBaseUrl())
-		assert.Equal(t, "/mock/v1/current.json", GetWeatherPath())
-		assert.Equal(t, "mock-key", GetWeatherAPIKey())
+		assert.Equal(t, "1234", c.WebServerPort)
+		assert.Equal(t, "http://mockviacep.com", c.ViaCepBaseUrl)
+		assert.Equal(t, "/mock/ws/%s/json", c.ViaCepPath)
+		assert.Equal(t, "http://mockweather.com", c.WeatherBaseUrl)
+		assert.Equal(t, "/mock/v1/current.json", c.WeatherPath)
+		assert.Equal(t, "mock-key", c.WeatherAPIKey)
+		assert.Equal(t, "http://mockbrasilapi.com", c.BrasilApiBaseUrl)
+		assert.Equal(t, "/mock/cep/%s", c.BrasilApiPath)
+		assert.Equal(t, "http://mockopencep.com", c.OpenCepBaseUrl)
+		assert.Equal(t, "/mock/v1/%s", c.OpenCepPath)
+		assert.Equal(t, []string{"opencep", "viacep"}, c.ZipCodeProviderNames())
+		assert.Equal(t, "/data/ceps.json", c.ZipCodeDatasetPath)
+		assert.Equal(t, []string{"openmeteo", "openweathermap"}, c.WeatherProviderNames())
+		assert.Equal(t, "http://mockgeocoding.com", c.OpenMeteoGeocodingBaseUrl)
+		assert.Equal(t, "/mock/search", c.OpenMeteoGeocodingPath)
+		assert.Equal(t, "http://mockopenmeteo.com", c.OpenMeteoBaseUrl)
+		assert.Equal(t, "/mock/forecast", c.OpenMeteoPath)
+		assert.Equal(t, "http://mockopenweathermap.com", c.OpenWeatherMapBaseUrl)
+		assert.Equal(t, "/mock/weather", c.OpenWeatherMapPath)
+		assert.Equal(t, "mock-owm-key", c.OpenWeatherMapAPIKey)
+		assert.Equal(t, 1*time.Second, c.ServerReadTimeout)
+		assert.Equal(t, 2*time.Second, c.ServerWriteTimeout)
+		assert.Equal(t, 3*time.Second, c.ServerIdleTimeout)
+		assert.Equal(t, 4*time.Second, c.ServerShutdownTimeout)
+		assert.Equal(t, 1500*time.Millisecond, c.RequestTimeout)
+		assert.Equal(t, 50, c.ViaCepCacheSize)
+		assert.Equal(t, time.Hour, c.ViaCepCacheTTL)
+		assert.Equal(t, time.Minute, c.ViaCepCacheNotFoundTTL)
+		assert.Equal(t, 20, c.WeatherCacheSize)
+		assert.Equal(t, 30*time.Second, c.WeatherCacheTTL)
+		assert.Equal(t, 2, c.ViaCepRetryMaxAttempts)
+		assert.Equal(t, 10*time.Millisecond, c.ViaCepRetryInitialBackoff)
+		assert.Equal(t, 20*time.Millisecond, c.ViaCepRetryMaxBackoff)
+		assert.Equal(t, 4, c.WeatherRetryMaxAttempts)
+		assert.Equal(t, 30*time.Millisecond, c.WeatherRetryInitialBackoff)
+		assert.Equal(t, 40*time.Millisecond, c.WeatherRetryMaxBackoff)
+		assert.Equal(t, 7, c.CircuitBreakerConsecutiveFailures)
+		assert.Equal(t, 0.8, c.CircuitBreakerFailureRatio)
+		assert.Equal(t, 20, c.CircuitBreakerMinRequests)
+		assert.Equal(t, 2*time.Minute, c.CircuitBreakerInterval)
+		assert.Equal(t, 15*time.Second, c.CircuitBreakerCoolDown)
+		assert.Equal(t, 2, c.CircuitBreakerHalfOpenMaxRequests)
+		assert.Equal(t, true, c.TemperaturePreciseKelvin)
+		assert.Equal(t, uint(2), c.TemperaturePrecision)
+		assert.Equal(t, 50, c.BatchMaxZipCodes)
+		assert.Equal(t, 4, c.BatchWorkers)
+		assert.Equal(t, "https://ibge.test", c.IbgeBaseUrl)
+		assert.Equal(t, "/municipios/%s", c.IbgePath)
+		assert.Equal(t, "/mock/forecast.json", c.WeatherForecastPath)
+		assert.Equal(t, 7, c.ForecastMaxDays)
+		assert.Equal(t, "/mock/history.json", c.WeatherHistoryPath)
+		assert.Equal(t, 30, c.HistoryMaxDays)
+		assert.Equal(t, "stdout", c.TracingExporter)
+		assert.Equal(t, "collector:4318", c.TracingOTLPEndpoint)
+		assert.Equal(t, "weather-test", c.TracingServiceName)
+		assert.Equal(t, "debug", c.LogLevel)
+		assert.Equal(t, "text", c.LogFormat)
+		assert.Equal(t, false, c.LogCloudLogging)
+		assert.Equal(t, "", c.WeatherAPIKeyFile)
 	})
-}
-
-func Test_RefreshConfig(t *testing.T) {
-	t.Run("When reload configs, should return new values", func(t *testing.T) {
-		setEnvMock()
-		defer unsetEnvMock()
 
-		_ = LoadConfig(".")
+	t.Run("When .env and the environment set a key, should prefer the environment", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"),
+			[]byte("WEB_SERVER_PORT=1111\nVIACEP_PATH=/file/%s\nWEATHER_API_KEY=file-key\n"), 0o600))
 
-		os.Setenv("WEB_SERVER_PORT", "7777")
-		os.Setenv("VIACEP_BASE_URL", "http://updated-viacep/")
+		c, warnings, err := load(dir, lookup(map[string]string{"WEB_SERVER_PORT": "2222", "VIACEP_PATH": ""}))
 
-		err := RefreshConfig()
 		assert.NoError(t, err)
-
-		assert.Equal(t, "7777", GetWebServerPort())
-		assert.Equal(t, "http://updated-viacep/", GetViaCepBaseUrl())
+		assert.Empty(t, warnings)
+		assert.Equal(t, "2222", c.WebServerPort)
+		assert.Equal(t, "/file/%s", c.ViaCepPath)
+		assert.Equal(t, "file-key", c.WeatherAPIKey)
 	})
 
-	t.Run("When reload configs and .env not found, should return default values", func(t *testing.T) {
-		setEnvMock()
-		_ = LoadConfig(".")
-		unsetEnvMock()
+	t.Run("When WEATHER_API_KEY_FILE is set, should read the key from the file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "weather-api-key")
+		require.NoError(t, os.WriteFile(path, []byte("secret-from-file\n"), 0o600))
+		env := envMock()
+		env["WEATHER_API_KEY_FILE"] = path
 
-		err := RefreshConfig()
-		assert.NoError(t, err)
+		c, _, err := load(".", lookup(env))
 
-		assert.Equal(t, "8080", GetWebServerPort())
-		assert.Equal(t, "https://viacep.com.br", GetViaCepBaseUrl())
-		assert.Equal(t, "/ws/%s/json", GetViaCepPath())
-		assert.Equal(t, "https://api.weatherapi.com", GetWeatherBaseUrl())
-		assert.Equal(t, "/v1/current.json", GetWeatherPath())
-		assert.Equal(t, "", GetWeatherAPIKey())
+		assert.NoError(t, err)
+		assert.Equal(t, path, c.WeatherAPIKeyFile)
+		assert.Equal(t, "secret-from-file", c.WeatherAPIKey)
 	})
-}
 
-func Test_SetAndGet(t *testing.T) {
-	t.Run("WebServerPort", func(t *testing.T) {
-		setEnvMock()
-		defer unsetEnvMock()
-		_ = LoadConfig(".")
+	t.Run("When WEATHER_API_KEY_FILE can not be read, should return an error", func(t *testing.T) {
+		t.Parallel()
+		env := envMock()
+		env["WEATHER_API_KEY_FILE"] = filepath.Join(t.TempDir(), "missing")
 
-		assert.Equal(t, "1234", GetWebServerPort())
-		SetWebServerPort("9999")
-		assert.Equal(t, "9999", GetWebServerPort())
-	})
-
-	t.Run("ViaCepBaseUrl", func(t *testing.T) {
-		setEnvMock()
-		defer unsetEnvMock()
-		_ = LoadConfig(".")
+		_, _, err := load(".", lookup(env))
 
-		assert.Equal(t, "http://mockviacep.com", GetViaCepBaseUrl())
-		SetViaCepBaseUrl("http://changed-viacep")
-		assert.Equal(t, "http://changed-viacep", GetViaCepBaseUrl())
+		assert.ErrorContains(t, err, "Error to read secret file")
 	})
+}
+
+func validConfig() Config {
+	return Config{
+		WebServerPort:              "8080",
+		ViaCepBaseUrl:              "https://viacep.com.br",
+		WeatherBaseUrl:             "https://api.weatherapi.com",
+		WeatherAPIKey:              "key",
+		WeatherProvider:            "weatherapi",
+		BrasilApiBaseUrl:           "https://brasilapi.com.br",
+		OpenCepBaseUrl:             "https://opencep.com",
+		OpenMeteoGeocodingBaseUrl:  "https://geocoding-api.open-meteo.com",
+		OpenMeteoBaseUrl:           "https://api.open-meteo.com",
+		OpenWeatherMapBaseUrl:      "https://api.openweathermap.org",
+		IbgeBaseUrl:                "https://servicodados.ibge.gov.br",
+		ServerWriteTimeout:         10 * time.Second,
+		ServerShutdownTimeout:      10 * time.Second,
+		RequestTimeout:             8 * time.Second,
+		ViaCepRetryMaxAttempts:     3,
+		WeatherRetryMaxAttempts:    3,
+		BatchMaxZipCodes:           100,
+		BatchWorkers:               10,
+		ForecastMaxDays:            3,
+		HistoryMaxDays:             7,
+		CircuitBreakerFailureRatio: 0.5,
+	}
+}
 
-	t.Run("ViaCepPath", func(t *testing.T) {
-		setEnvMock()
-		defer unsetEnvMock()
-		_ = LoadConfig(".")
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
 
-		assert.Equal(t, "/mock/ws/%s/json", GetViaCepPath())
-		SetViaCepPath("/changed-mock/ws/%s/json")
-		assert.Equal(t, "/changed-mock/ws/%s/json", GetViaCepPath())
+	t.Run("Should accept the config When every setting is usable", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, validConfig().Validate())
 	})
 
-	t.Run("WeatherBaseUrl", func(t *testing.T) {
-		setEnvMock()
-		defer unsetEnvMock()
-		_ = LoadConfig(".")
+	t.Run("Should not require API keys When their provider is not configured", func(t *testing.T) {
+		t.Parallel()
+		c := validConfig()
+		c.WeatherProvider = "openmeteo"
+		c.WeatherAPIKey = ""
 
-		assert.Equal(t, "http://mockweather.com", GetWeatherBaseUrl())
-		SetWeatherBaseUrl("http://changed-weather")
-		assert.Equal(t, "http://changed-weather", GetWeatherBaseUrl())
+		assert.NoError(t, c.Validate())
 	})
 
-	t.Run("WeatherPath", func(t *testing.T) {
-		setEnvMock()
-		defer unsetEnvMock()
-		_ = LoadConfig(".")
+	t.Run("Should report every problem When several settings are unusable", func(t *testing.T) {
+		t.Parallel()
+		c := validConfig()
+		c.WebServerPort = ""
+		c.ViaCepBaseUrl = "viacep.com.br"
+		c.BatchWorkers = 0
+		c.WeatherCacheSize = -1
+		c.CircuitBreakerFailureRatio = 1.5
+		c.ServerShutdownTimeout = 0
+		c.RequestTimeout = c.ServerWriteTimeout
+		c.WeatherProvider = "weatherapi,openweathermap"
+		c.WeatherAPIKey = ""
+
+		err := c.Validate()
 
-		assert.Equal(t, "/mock/v1/current.json", GetWeatherPath())
-		SetWeatherPath("/changed-mock/v1/current.json")
-		assert.Equal(t, "/changed-mock/v1/current.json", GetWeatherPath())
+		assert.EqualError(t, err, "invalid config: "+strings.Join([]string{
+			"WEB_SERVER_PORT must be set",
+			`VIACEP_BASE_URL must be an absolute URL, got "viacep.com.br"`,
+			"BATCH_WORKERS must be at least 1, got 0",
+			"cache sizes must not be negative",
+			"CIRCUIT_BREAKER_FAILURE_RATIO must be between 0 and 1, got 1.5",
+			"SERVER_SHUTDOWN_TIMEOUT must be positive",
+			"REQUEST_TIMEOUT must be below SERVER_WRITE_TIMEOUT, got 10s and 10s",
+			"WEATHER_API_KEY must be set when weatherapi is a weather provider",
+			"OPENWEATHERMAP_API_KEY must be set when openweathermap is a weather provider",
+		}, "\n"))
 	})
+}
+
+func TestConfig_ProviderNames(t *testing.T) {
+	t.Parallel()
 
-	t.Run("WeatherAPIKey", func(t *testing.T) {
-		setEnvMock()
-		defer unsetEnvMock()
-		_ = LoadConfig(".")
+	t.Run("Should trim, lower and drop empty names", func(t *testing.T) {
+		t.Parallel()
+		c := Config{ZipCodeProviders: " ViaCep, ,local ", WeatherProvider: "OpenMeteo,weatherapi"}
 
-		assert.Equal(t, "mock-key", GetWeatherAPIKey())
-		SetWeatherAPIKey("NEW-KEY")
-		assert.Equal(t, "NEW-KEY", GetWeatherAPIKey())
+		assert.Equal(t, []string{"viacep", "local"}, c.ZipCodeProviderNames())
+		assert.Equal(t, []string{"openmeteo", "weatherapi"}, c.WeatherProviderNames())
 	})
 }
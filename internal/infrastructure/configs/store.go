@@ -0,0 +1,53 @@
+package configs
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// Store holds the current configuration snapshot. Reload loads the
+// configuration again and swaps the snapshot whole, and only when the new one
+// is valid, so readers never see a partial or invalid configuration.
+type Store struct {
+	path      string
+	lookupEnv func(key string) (string, bool)
+	current   atomic.Pointer[Config]
+
+	// reloadMu serializes reloads, so the last one loaded is the one kept.
+	reloadMu sync.Mutex
+}
+
+// NewStore loads the configuration from the .env file in path and the
+// environment, as Load does, and keeps it as the first snapshot.
+func NewStore(path string) (*Store, []error, error) {
+	return newStore(path, os.LookupEnv)
+}
+
+func newStore(path string, lookupEnv func(key string) (string, bool)) (*Store, []error, error) {
+	s := &Store{path: path, lookupEnv: lookupEnv}
+	_, warnings, err := s.Reload()
+	if err != nil {
+		return nil, warnings, err
+	}
+	return s, warnings, nil
+}
+
+// Reload loads the configuration again and makes it the current snapshot. On
+// error the current snapshot is kept.
+func (s *Store) Reload() (Config, []error, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	c, warnings, err := load(s.path, s.lookupEnv)
+	if err != nil {
+		return Config{}, warnings, err
+	}
+	s.current.Store(&c)
+	return c, warnings, nil
+}
+
+// Current returns the last configuration loaded.
+func (s *Store) Current() Config {
+	return *s.current.Load()
+}
@@ -0,0 +1,102 @@
+package configs
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Reload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("When reload configs, should swap the current snapshot", func(t *testing.T) {
+		t.Parallel()
+		env := envMock()
+		store, _, err := newStore(".", lookup(env))
+		require.NoError(t, err)
+		loaded := store.Current()
+
+		env["BATCH_MAX_ZIP_CODES"] = "10"
+		env["VIACEP_BASE_URL"] = "http://updated-viacep"
+		reloaded, _, err := store.Reload()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, reloaded.BatchMaxZipCodes)
+		assert.Equal(t, "http://updated-viacep", reloaded.ViaCepBaseUrl)
+		assert.Equal(t, reloaded, store.Current())
+		assert.Equal(t, 50, loaded.BatchMaxZipCodes)
+	})
+
+	t.Run("When the reloaded config is invalid, should keep the current snapshot", func(t *testing.T) {
+		t.Parallel()
+		env := envMock()
+		store, _, err := newStore(".", lookup(env))
+		require.NoError(t, err)
+		loaded := store.Current()
+
+		env["BATCH_WORKERS"] = "0"
+		_, _, err = store.Reload()
+
+		assert.ErrorContains(t, err, "BATCH_WORKERS must be at least 1, got 0")
+		assert.Equal(t, loaded, store.Current())
+	})
+
+	t.Run("When the first config is invalid, should return an error", func(t *testing.T) {
+		t.Parallel()
+		env := envMock()
+		env["BATCH_WORKERS"] = "0"
+
+		store, _, err := newStore(".", lookup(env))
+
+		assert.Nil(t, store)
+		assert.ErrorContains(t, err, "BATCH_WORKERS must be at least 1, got 0")
+	})
+
+	t.Run("When read while reloading, should always return a whole snapshot", func(t *testing.T) {
+		t.Parallel()
+		var mu sync.Mutex
+		env := envMock()
+		store, _, err := newStore(".", func(key string) (string, bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			value, ok := env[key]
+			return value, ok
+		})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					c := store.Current()
+					assert.NoError(t, c.Validate())
+					assert.Equal(t, c.BatchMaxZipCodes == 50, c.ViaCepBaseUrl == "http://mockviacep.com")
+				}
+			}()
+		}
+		for i := 0; i < 20; i++ {
+			mu.Lock()
+			if i%2 == 0 {
+				env["BATCH_MAX_ZIP_CODES"] = "10"
+				env["VIACEP_BASE_URL"] = "http://updated-viacep"
+			} else {
+				env = envMock()
+			}
+			mu.Unlock()
+			_, _, err := store.Reload()
+			assert.NoError(t, err)
+		}
+		close(done)
+		wg.Wait()
+	})
+}
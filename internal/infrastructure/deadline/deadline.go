@@ -0,0 +1,24 @@
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Middleware bounds the time a request may spend on upstream calls. The
+// deadline is set on the request context, so retries stop waiting, provider
+// chains stop failing over and the client gets a 504 before the server write
+// timeout resets the connection. A timeout of zero or less sets no deadline.
+func Middleware(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
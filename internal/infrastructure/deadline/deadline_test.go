@@ -0,0 +1,38 @@
+package deadline_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/deadline"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(timeout time.Duration) (time.Time, bool) {
+	var (
+		until       time.Time
+		hasDeadline bool
+	)
+	handler := deadline.Middleware(timeout)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		until, hasDeadline = r.Context().Deadline()
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/temperature/12345678", nil))
+	return until, hasDeadline
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Should set the request deadline When a timeout is configured", func(t *testing.T) {
+		start := time.Now()
+		until, hasDeadline := serve(2 * time.Second)
+
+		assert.True(t, hasDeadline)
+		assert.WithinDuration(t, start.Add(2*time.Second), until, time.Second)
+	})
+
+	t.Run("Should set no deadline When the timeout is zero", func(t *testing.T) {
+		_, hasDeadline := serve(0)
+		assert.False(t, hasDeadline)
+	})
+}
@@ -1,38 +1,295 @@
 package dependencies
 
 import (
+	"context"
+	"log/slog"
 	"time"
 
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
 	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/configs"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/metrics"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/tracing"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Handlers struct {
-	GetTemperatureByZipCodeHandler handler.GetTemperatureByZipCodeHandler
-	GetStatusHandler               handler.GetStatusHandler
+	GetTemperatureByZipCodeHandler     handler.GetTemperatureByZipCodeHandler
+	GetTemperaturesByZipCodesHandler   handler.GetTemperaturesByZipCodesHandler
+	GetTemperatureByCityHandler        handler.GetTemperatureByCityHandler
+	GetTemperatureByCoordinatesHandler handler.GetTemperatureByCoordinatesHandler
+	GetTemperatureByIBGECodeHandler    handler.GetTemperatureByIBGECodeHandler
+	GetForecastByZipCodeHandler        handler.GetForecastByZipCodeHandler
+	GetConditionsByZipCodeHandler      handler.GetConditionsByZipCodeHandler
+	GetHistoryByZipCodeHandler         handler.GetHistoryByZipCodeHandler
+	GetAirQualityByZipCodeHandler      handler.GetAirQualityByZipCodeHandler
+	GetStatusHandler                   handler.GetStatusHandler
+	GetMetricsHandler                  handler.GetMetricsHandler
+	HTTPMetrics                        *metrics.HTTPMetrics
+	TracerProvider                     *sdktrace.TracerProvider
+	Logger                             *slog.Logger
+	RequestTimeout                     time.Duration
 }
 
-func BuildDependencies() *Handlers {
+// observers gathers what upstream clients and provider chains report to.
+type observers struct {
+	statusReporters map[string]handler.StatusReporter
+	upstreamMetrics *metrics.UpstreamMetrics
+	tracerProvider  trace.TracerProvider
+	logger          *slog.Logger
+}
+
+// BuildDependencies wires the application from cfg, which is read only here:
+// every component gets the settings it needs when it is built. logger is
+// injected into the services and usecases that log.
+func BuildDependencies(cfg configs.Config, logger *slog.Logger) *Handlers {
+	statusReporters := map[string]handler.StatusReporter{}
+	registry := metrics.NewRegistry()
+	tracerProvider := buildTracerProvider(cfg, logger)
+	observers := observers{
+		statusReporters: statusReporters,
+		upstreamMetrics: metrics.NewUpstreamMetrics(registry),
+		tracerProvider:  tracerProvider,
+		logger:          logger,
+	}
+
 	// --- Clients ---
 	httpClient := config.NewHTTPClient(3 * time.Second)
+	zipCodeRetryPolicy := config.RetryPolicy{
+		MaxAttempts:    cfg.ViaCepRetryMaxAttempts,
+		InitialBackoff: cfg.ViaCepRetryInitialBackoff,
+		MaxBackoff:     cfg.ViaCepRetryMaxBackoff,
+	}
+	weatherRetryPolicy := config.RetryPolicy{
+		MaxAttempts:    cfg.WeatherRetryMaxAttempts,
+		InitialBackoff: cfg.WeatherRetryInitialBackoff,
+		MaxBackoff:     cfg.WeatherRetryMaxBackoff,
+	}
 
 	// --- Repositories ---
 
 	// --- Services ---
-	viaCepService := service.NewViaCepService(httpClient)
-	weatherService := service.NewWeatherService(httpClient)
+	viaCepService := buildZipCodeService(cfg, httpClient, zipCodeRetryPolicy, observers)
+	if cfg.ViaCepCacheSize > 0 {
+		cachedViaCepService := service.NewCachedViaCepService(viaCepService,
+			cfg.ViaCepCacheSize, cfg.ViaCepCacheTTL, cfg.ViaCepCacheNotFoundTTL)
+		statusReporters["via_cep_cache"] = handler.StatusReporterFunc(func() interface{} {
+			return cachedViaCepService.Stats()
+		})
+		viaCepService = cachedViaCepService
+	}
+
+	cityService := service.NewIbgeCityService(buildUpstreamClient(cfg, httpClient, zipCodeRetryPolicy, "ibge", observers),
+		service.EndpointSettings{BaseURL: cfg.IbgeBaseUrl, Path: cfg.IbgePath})
+
+	weatherService := buildWeatherService(cfg, httpClient, weatherRetryPolicy, observers)
+	if cfg.WeatherCacheSize > 0 {
+		cachedWeatherService := service.NewCachedWeatherService(weatherService,
+			cfg.WeatherCacheSize, cfg.WeatherCacheTTL, cfg.RequestTimeout)
+		statusReporters["weather_cache"] = handler.StatusReporterFunc(func() interface{} {
+			return cachedWeatherService.Stats()
+		})
+		weatherService = cachedWeatherService
+	}
 
 	// --- UseCases ---
-	getTemperatureByZipCodeUsecase := usecase.NewGetTemperatureByZipCodeUsecase(viaCepService, weatherService)
+	temperatureOptions := model.TemperatureOptions{
+		PreciseKelvin: cfg.TemperaturePreciseKelvin,
+		Precision:     cfg.TemperaturePrecision,
+	}
+	tracer := tracerProvider.Tracer(tracing.InstrumentationName)
+	getTemperatureByZipCodeUsecase := usecase.NewTracedGetTemperatureByZipCodeUsecase(
+		usecase.NewGetTemperatureByZipCodeUsecase(viaCepService, weatherService, temperatureOptions, logger), tracer)
+	getForecastByZipCodeUsecase := usecase.NewTracedGetForecastByZipCodeUsecase(
+		usecase.NewGetForecastByZipCodeUsecase(viaCepService, weatherService, temperatureOptions, logger), tracer)
+	getConditionsByZipCodeUsecase := usecase.NewTracedGetConditionsByZipCodeUsecase(
+		usecase.NewGetConditionsByZipCodeUsecase(viaCepService, weatherService, temperatureOptions, logger), tracer)
+	getHistoryByZipCodeUsecase := usecase.NewTracedGetHistoryByZipCodeUsecase(
+		usecase.NewGetHistoryByZipCodeUsecase(viaCepService, weatherService, temperatureOptions, logger), tracer)
+	getAirQualityByZipCodeUsecase := usecase.NewTracedGetAirQualityByZipCodeUsecase(
+		usecase.NewGetAirQualityByZipCodeUsecase(viaCepService, weatherService, logger), tracer)
+	getTemperaturesByZipCodesUsecase := usecase.NewGetTemperaturesByZipCodesUsecase(getTemperatureByZipCodeUsecase, cfg.BatchWorkers)
+	getTemperatureByLocationUsecase := usecase.NewGetTemperatureByLocationUsecase(cityService, weatherService, temperatureOptions)
 
 	// --- Handlers ---
 	getTemperatureByZipCodeHandler := handler.NewGetTemperatureByZipCodeHandler(getTemperatureByZipCodeUsecase)
-	getStatusHandler := handler.NewGetStatusHandler()
+	getTemperaturesByZipCodesHandler := handler.NewGetTemperaturesByZipCodesHandler(getTemperaturesByZipCodesUsecase, cfg.BatchMaxZipCodes)
+	getTemperatureByCityHandler := handler.NewGetTemperatureByCityHandler(getTemperatureByLocationUsecase)
+	getTemperatureByCoordinatesHandler := handler.NewGetTemperatureByCoordinatesHandler(getTemperatureByLocationUsecase)
+	getTemperatureByIBGECodeHandler := handler.NewGetTemperatureByIBGECodeHandler(getTemperatureByLocationUsecase)
+	getForecastByZipCodeHandler := handler.NewGetForecastByZipCodeHandler(getForecastByZipCodeUsecase, cfg.ForecastMaxDays)
+	getConditionsByZipCodeHandler := handler.NewGetConditionsByZipCodeHandler(getConditionsByZipCodeUsecase)
+	getHistoryByZipCodeHandler := handler.NewGetHistoryByZipCodeHandler(getHistoryByZipCodeUsecase, cfg.HistoryMaxDays)
+	getAirQualityByZipCodeHandler := handler.NewGetAirQualityByZipCodeHandler(getAirQualityByZipCodeUsecase)
+	getStatusHandler := handler.NewGetStatusHandler(statusReporters)
+	getMetricsHandler := handler.NewGetMetricsHandler(registry, logger)
 
 	return &Handlers{
-		GetTemperatureByZipCodeHandler: getTemperatureByZipCodeHandler,
-		GetStatusHandler:               getStatusHandler,
+		GetTemperatureByZipCodeHandler:     getTemperatureByZipCodeHandler,
+		GetTemperaturesByZipCodesHandler:   getTemperaturesByZipCodesHandler,
+		GetTemperatureByCityHandler:        getTemperatureByCityHandler,
+		GetTemperatureByCoordinatesHandler: getTemperatureByCoordinatesHandler,
+		GetTemperatureByIBGECodeHandler:    getTemperatureByIBGECodeHandler,
+		GetForecastByZipCodeHandler:        getForecastByZipCodeHandler,
+		GetConditionsByZipCodeHandler:      getConditionsByZipCodeHandler,
+		GetHistoryByZipCodeHandler:         getHistoryByZipCodeHandler,
+		GetAirQualityByZipCodeHandler:      getAirQualityByZipCodeHandler,
+		GetStatusHandler:                   getStatusHandler,
+		GetMetricsHandler:                  getMetricsHandler,
+		HTTPMetrics:                        metrics.NewHTTPMetrics(registry),
+		TracerProvider:                     tracerProvider,
+		Logger:                             logger,
+		RequestTimeout:                     cfg.RequestTimeout,
+	}
+}
+
+// buildZipCodeService assembles the providers listed in ZIPCODE_PROVIDERS, in
+// order, behind a fallback chain. Unknown or unavailable providers are skipped.
+func buildZipCodeService(cfg configs.Config, httpClient config.HTTPDoer, retryPolicy config.RetryPolicy, observers observers) gateway.ViaCepService {
+	providers := []service.ZipCodeProvider{}
+	for _, name := range cfg.ZipCodeProviderNames() {
+		switch name {
+		case "viacep":
+			client := buildUpstreamClient(cfg, httpClient, retryPolicy, "via_cep", observers)
+			providers = append(providers, service.ZipCodeProvider{Name: name, Service: service.NewViaCepService(client, service.EndpointSettings{BaseURL: cfg.ViaCepBaseUrl, Path: cfg.ViaCepPath})})
+		case "brasilapi":
+			client := buildUpstreamClient(cfg, httpClient, retryPolicy, "brasil_api", observers)
+			providers = append(providers, service.ZipCodeProvider{Name: name, Service: service.NewBrasilApiCepService(client, service.EndpointSettings{BaseURL: cfg.BrasilApiBaseUrl, Path: cfg.BrasilApiPath})})
+		case "opencep":
+			client := buildUpstreamClient(cfg, httpClient, retryPolicy, "open_cep", observers)
+			providers = append(providers, service.ZipCodeProvider{Name: name, Service: service.NewOpenCepService(client, service.EndpointSettings{BaseURL: cfg.OpenCepBaseUrl, Path: cfg.OpenCepPath})})
+		case "local":
+			if cfg.ZipCodeDatasetPath == "" {
+				continue
+			}
+			localService, err := service.NewLocalZipCodeService(cfg.ZipCodeDatasetPath)
+			if err != nil {
+				observers.logger.Warn("local zip code provider disabled", "error", err)
+				continue
+			}
+			providers = append(providers, service.ZipCodeProvider{Name: name, Service: localService})
+		default:
+			observers.logger.Warn("unknown zip code provider ignored", "provider", name)
+		}
+	}
+
+	if len(providers) == 0 {
+		observers.logger.Warn("no zip code provider configured, using viacep")
+		client := buildUpstreamClient(cfg, httpClient, retryPolicy, "via_cep", observers)
+		return service.NewViaCepService(client, service.EndpointSettings{BaseURL: cfg.ViaCepBaseUrl, Path: cfg.ViaCepPath})
+	}
+	if len(providers) == 1 {
+		return providers[0].Service
+	}
+
+	chain := service.NewZipCodeProviderChain(observers.logger, providers...)
+	observers.statusReporters["zip_code_providers"] = handler.StatusReporterFunc(func() interface{} {
+		return chain.Stats()
+	})
+	return chain
+}
+
+// buildWeatherService assembles the providers listed in WEATHER_PROVIDER, in
+// order, behind a failover chain. Unknown providers are skipped.
+func buildWeatherService(cfg configs.Config, httpClient config.HTTPDoer, retryPolicy config.RetryPolicy, observers observers) gateway.WeatherService {
+	providers := []service.WeatherProvider{}
+	for _, name := range cfg.WeatherProviderNames() {
+		switch name {
+		case "weatherapi":
+			client := buildUpstreamClient(cfg, httpClient, retryPolicy, "weather", observers)
+			providers = append(providers, service.WeatherProvider{Name: name, Service: service.NewWeatherService(client, weatherAPISettings(cfg))})
+		case "openmeteo":
+			client := buildUpstreamClient(cfg, httpClient, retryPolicy, "open_meteo", observers)
+			settings := service.OpenMeteoSettings{
+				GeocodingBaseURL: cfg.OpenMeteoGeocodingBaseUrl,
+				GeocodingPath:    cfg.OpenMeteoGeocodingPath,
+				BaseURL:          cfg.OpenMeteoBaseUrl,
+				Path:             cfg.OpenMeteoPath,
+			}
+			providers = append(providers, service.WeatherProvider{Name: name, Service: service.NewOpenMeteoService(client, settings)})
+		case "openweathermap":
+			client := buildUpstreamClient(cfg, httpClient, retryPolicy, "open_weather_map", observers)
+			settings := service.OpenWeatherMapSettings{
+				BaseURL: cfg.OpenWeatherMapBaseUrl,
+				Path:    cfg.OpenWeatherMapPath,
+				APIKey:  cfg.OpenWeatherMapAPIKey,
+			}
+			providers = append(providers, service.WeatherProvider{Name: name, Service: service.NewOpenWeatherMapService(client, settings)})
+		default:
+			observers.logger.Warn("unknown weather provider ignored", "provider", name)
+		}
+	}
+
+	if len(providers) == 0 {
+		observers.logger.Warn("no weather provider configured, using weatherapi")
+		client := buildUpstreamClient(cfg, httpClient, retryPolicy, "weather", observers)
+		return service.NewWeatherService(client, weatherAPISettings(cfg))
+	}
+	if len(providers) == 1 {
+		return providers[0].Service
+	}
+
+	chain := service.NewWeatherProviderChain(observers.logger, providers...)
+	observers.statusReporters["weather_providers"] = handler.StatusReporterFunc(func() interface{} {
+		return chain.Stats()
+	})
+	return chain
+}
+
+// buildUpstreamClient wraps httpClient with retries and a circuit breaker
+// whose state is reported on /status as "<name>_circuit_breaker". Calls are
+// counted on /metrics under the upstream label name, and every attempt is
+// traced and carries the request ID. API keys are redacted from transport
+// errors before any of these layers sees them.
+func buildUpstreamClient(cfg configs.Config, httpClient config.HTTPDoer, retryPolicy config.RetryPolicy, name string, observers observers) config.HTTPDoer {
+	redacted := config.NewRedactingHTTPDoer(httpClient)
+	traced := config.NewTracingHTTPDoer(config.NewRequestIDHTTPDoer(redacted), name, observers.tracerProvider)
+	breaker := config.NewCircuitBreakerHTTPDoer(config.NewRetryHTTPDoer(traced, retryPolicy), circuitBreakerSettings(cfg))
+	observers.statusReporters[name+"_circuit_breaker"] = handler.StatusReporterFunc(func() interface{} {
+		return breaker.Status()
+	})
+	return config.NewInstrumentedHTTPDoer(breaker, name, observers.upstreamMetrics)
+}
+
+func weatherAPISettings(cfg configs.Config) service.WeatherAPISettings {
+	return service.WeatherAPISettings{
+		BaseURL:      cfg.WeatherBaseUrl,
+		CurrentPath:  cfg.WeatherPath,
+		ForecastPath: cfg.WeatherForecastPath,
+		HistoryPath:  cfg.WeatherHistoryPath,
+		APIKey:       cfg.WeatherAPIKey,
+	}
+}
+
+func circuitBreakerSettings(cfg configs.Config) config.CircuitBreakerSettings {
+	return config.CircuitBreakerSettings{
+		ConsecutiveFailures: cfg.CircuitBreakerConsecutiveFailures,
+		FailureRatio:        cfg.CircuitBreakerFailureRatio,
+		MinRequests:         cfg.CircuitBreakerMinRequests,
+		Interval:            cfg.CircuitBreakerInterval,
+		CoolDown:            cfg.CircuitBreakerCoolDown,
+		HalfOpenMaxRequests: cfg.CircuitBreakerHalfOpenMaxRequests,
+	}
+}
+
+// buildTracerProvider falls back to not exporting spans when the configured
+// exporter can not be created, rather than failing to start.
+func buildTracerProvider(cfg configs.Config, logger *slog.Logger) *sdktrace.TracerProvider {
+	settings := tracing.Settings{
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.TracingOTLPEndpoint,
+		ServiceName:  cfg.TracingServiceName,
+	}
+	provider, err := tracing.NewTracerProvider(context.Background(), settings)
+	if err != nil {
+		logger.Warn("tracing exporter disabled", "error", err)
+		settings.Exporter = tracing.ExporterNone
+		provider, _ = tracing.NewTracerProvider(context.Background(), settings)
 	}
+	return provider
 }
@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/requestid"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// contextHandler adds to each record the request ID, route, zip code and
+// trace ID found in its context. Components only need a logger injected once
+// and to log with the request context to get request scoped entries.
+type contextHandler struct {
+	next slog.Handler
+}
+
+func (h *contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		record.AddAttrs(requestAttrs(ctx)...)
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{next: h.next.WithGroup(name)}
+}
+
+func requestAttrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	if requestID := requestid.FromContext(ctx); requestID != "" {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+	if routeContext := chi.RouteContext(ctx); routeContext != nil {
+		if route := routeContext.RoutePattern(); route != "" {
+			attrs = append(attrs, slog.String("route", route))
+		}
+		if zipCode := routeContext.URLParam("zipCode"); zipCode != "" {
+			attrs = append(attrs, slog.String("zip_code", zipCode))
+		}
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		attrs = append(attrs,
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()))
+	}
+	return attrs
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/redact"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Settings struct {
+	Level        string
+	Format       string
+	CloudLogging bool
+}
+
+// New builds the service logger. Every record logged with a request context
+// carries the request scoped fields, see contextHandler, and API keys quoted
+// in messages or errors are redacted.
+func New(w io.Writer, settings Settings) (*slog.Logger, error) {
+	level, err := parseLevel(settings.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+	if settings.CloudLogging {
+		options.ReplaceAttr = func(groups []string, attr slog.Attr) slog.Attr {
+			return cloudLoggingAttr(groups, redactAttr(groups, attr))
+		}
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(settings.Format) {
+	case FormatJSON, "":
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", settings.Format)
+	}
+	return slog.New(&contextHandler{next: handler}), nil
+}
+
+func parseLevel(level string) (slog.Level, error) {
+	var parsed slog.Level
+	if level == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+	return parsed, nil
+}
+
+// redactAttr hides the secrets of string and error values, which is where
+// upstream URLs end up.
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		attr.Value = slog.StringValue(redact.String(attr.Value.String()))
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			attr.Value = slog.StringValue(redact.String(err.Error()))
+		}
+	}
+	return attr
+}
+
+// cloudLoggingAttr renames the built-in keys to the ones Cloud Logging maps to
+// the entry severity, message and timestamp. WARN becomes WARNING, the only
+// level whose name differs.
+func cloudLoggingAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return attr
+	}
+
+	switch attr.Key {
+	case slog.LevelKey:
+		attr.Key = "severity"
+		if level, ok := attr.Value.Any().(slog.Level); ok && level >= slog.LevelWarn && level < slog.LevelError {
+			attr.Value = slog.StringValue("WARNING")
+		}
+	case slog.MessageKey:
+		attr.Key = "message"
+	case slog.TimeKey:
+		attr.Key = "timestamp"
+	}
+	return attr
+}
@@ -0,0 +1,88 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, out *bytes.Buffer) []map[string]any {
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Should use Cloud Logging field names When cloud logging is on", func(t *testing.T) {
+		var out bytes.Buffer
+		logger, err := logging.New(&out, logging.Settings{Level: "debug", Format: "json", CloudLogging: true})
+		require.NoError(t, err)
+
+		logger.Warn("provider failed", "provider", "viacep")
+
+		entries := decodeLines(t, &out)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "WARNING", entries[0]["severity"])
+		assert.Equal(t, "provider failed", entries[0]["message"])
+		assert.Contains(t, entries[0], "timestamp")
+		assert.Equal(t, "viacep", entries[0]["provider"])
+		assert.NotContains(t, entries[0], "level")
+	})
+
+	t.Run("Should keep slog field names and filter by level When cloud logging is off", func(t *testing.T) {
+		var out bytes.Buffer
+		logger, err := logging.New(&out, logging.Settings{Level: "warn", Format: "json"})
+		require.NoError(t, err)
+
+		logger.Info("dropped")
+		logger.Error("kept")
+
+		entries := decodeLines(t, &out)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "ERROR", entries[0]["level"])
+		assert.Equal(t, "kept", entries[0]["msg"])
+	})
+
+	t.Run("Should write text When format is text", func(t *testing.T) {
+		var out bytes.Buffer
+		logger, err := logging.New(&out, logging.Settings{Format: "text"})
+		require.NoError(t, err)
+
+		logger.InfoContext(context.Background(), "server started", "port", "8080")
+
+		assert.Contains(t, out.String(), `level=INFO msg="server started" port=8080`)
+	})
+
+	t.Run("Should return an error When level or format is unknown", func(t *testing.T) {
+		_, levelErr := logging.New(&bytes.Buffer{}, logging.Settings{Level: "verbose"})
+		_, formatErr := logging.New(&bytes.Buffer{}, logging.Settings{Format: "xml"})
+
+		assert.EqualError(t, levelErr, `unknown log level "verbose"`)
+		assert.EqualError(t, formatErr, `unknown log format "xml"`)
+	})
+	t.Run("Should redact API keys When messages or errors quote an upstream URL", func(t *testing.T) {
+		for _, cloudLogging := range []bool{true, false} {
+			var out bytes.Buffer
+			logger, err := logging.New(&out, logging.Settings{Format: "json", CloudLogging: cloudLogging})
+			require.NoError(t, err)
+			upstreamErr := &url.Error{Op: "Get", URL: "https://api.weatherapi.com/v1/current.json?key=s3cr3t&q=x", Err: errors.New("EOF")}
+
+			logger.Error("weather provider failed: "+upstreamErr.Error(), "error", upstreamErr, "cause", upstreamErr.Error())
+
+			assert.NotContains(t, out.String(), "s3cr3t")
+			assert.Equal(t, 3, strings.Count(out.String(), "key=REDACTED"))
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package logging_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/logging"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/requestid"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/tracing"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := logging.New(&out, logging.Settings{Level: "info", Format: "json", CloudLogging: true})
+	require.NoError(t, err)
+	provider, _ := tracing.NewInMemoryTracerProvider()
+
+	router := chi.NewRouter()
+	router.Use(requestid.Middleware)
+	router.Use(tracing.Middleware(provider))
+	router.Use(logging.Middleware(logger))
+	router.Get("/temperature/{zipCode}", func(w http.ResponseWriter, r *http.Request) {
+		logger.InfoContext(r.Context(), "looking up")
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("upstream down"))
+	})
+
+	t.Run("should scope handler logs and the access log to the request", func(t *testing.T) {
+		out.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/temperature/90040000", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		entries := decodeLines(t, &out)
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			assert.Equal(t, "/temperature/{zipCode}", entry["route"])
+			assert.Equal(t, "90040000", entry["zip_code"])
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry["trace_id"])
+			assert.NotEmpty(t, entry["request_id"])
+		}
+		assert.Equal(t, entries[0]["request_id"], entries[1]["request_id"])
+
+		access := entries[1]
+		assert.Equal(t, "request served", access["message"])
+		assert.Equal(t, "ERROR", access["severity"])
+		assert.Equal(t, "GET", access["method"])
+		assert.Equal(t, "/temperature/90040000", access["path"])
+		assert.Equal(t, float64(http.StatusBadGateway), access["status"])
+		assert.Equal(t, float64(len("upstream down")), access["bytes"])
+	})
+
+	t.Run("should not add request fields outside a request", func(t *testing.T) {
+		out.Reset()
+
+		logger.Info("starting server")
+
+		entries := decodeLines(t, &out)
+		require.Len(t, entries, 1)
+		assert.NotContains(t, entries[0], "request_id")
+		assert.NotContains(t, entries[0], "trace_id")
+	})
+}
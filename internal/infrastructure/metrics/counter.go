@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"sync"
+)
+
+type CounterVec struct {
+	desc
+	mu     sync.Mutex
+	series map[string]*counterSeries
+}
+
+type counterSeries struct {
+	labelValues []string
+	value       float64
+}
+
+// NewCounterVec registers a counter partitioned by labels.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{metricName: name, help: help, labels: labels},
+		series: map[string]*counterSeries{},
+	}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the series identified by labelValues, given in the order the
+// labels were declared.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v, which must not be negative, to the series.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		panic(fmt.Sprintf("metrics: %s can not decrease", c.metricName))
+	}
+	key := c.seriesKey(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.series[key]
+	if !ok {
+		s = &counterSeries{labelValues: append([]string(nil), labelValues...)}
+		c.series[key] = s
+	}
+	s.value += v
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.writeHeader(w, "counter")
+	for _, key := range sortedKeys(c.series) {
+		s := c.series[key]
+		fmt.Fprintf(w, "%s%s %s\n", c.metricName, c.labelPairs(s.labelValues), formatFloat(s.value))
+	}
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+type histogramSeries struct {
+	labelValues []string
+	// counts[i] is the number of observations in (buckets[i-1], buckets[i]];
+	// the last slot holds those above every bucket.
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewHistogramVec registers a histogram partitioned by labels. buckets are the
+// upper bounds, sorted here; +Inf is implied.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	if n := len(sorted); n > 0 && math.IsInf(sorted[n-1], 1) {
+		sorted = sorted[:n-1]
+	}
+
+	h := &HistogramVec{
+		desc:    desc{metricName: name, help: help, labels: labels},
+		buckets: sorted,
+		series:  map[string]*histogramSeries{},
+	}
+	r.register(h)
+	return h
+}
+
+// Observe records v in the series identified by labelValues.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	key := h.seriesKey(labelValues)
+	bucket := sort.SearchFloat64s(h.buckets, v)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)+1),
+		}
+		h.series[key] = s
+	}
+	s.counts[bucket]++
+	s.sum += v
+	s.count++
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.writeHeader(w, "histogram")
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		var cumulative uint64
+		for i, upperBound := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, h.labelPairs(s.labelValues, "le", formatFloat(upperBound)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, h.labelPairs(s.labelValues, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.metricName, h.labelPairs(s.labelValues), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.metricName, h.labelPairs(s.labelValues), s.count)
+	}
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// unmatchedRoute labels requests no route matched, so probing random paths
+// can not blow up the number of series.
+const unmatchedRoute = "unmatched"
+
+// otherMethod labels requests with a method outside the standard ones, for
+// the same reason: clients can send any token as the method.
+const otherMethod = "other"
+
+var knownMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+type HTTPMetrics struct {
+	requests *CounterVec
+	duration *HistogramVec
+}
+
+func NewHTTPMetrics(registry *Registry) *HTTPMetrics {
+	return &HTTPMetrics{
+		requests: registry.NewCounterVec("http_requests_total",
+			"HTTP requests served, by method, route pattern and status.", "method", "route", "status"),
+		duration: registry.NewHistogramVec("http_request_duration_seconds",
+			"HTTP request latency, by method, route pattern and status.", DefaultBuckets, "method", "route", "status"),
+	}
+}
+
+func (m *HTTPMetrics) Observe(method, route string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	m.requests.Inc(method, route, statusLabel)
+	m.duration.Observe(duration.Seconds(), method, route, statusLabel)
+}
+
+// Middleware records every request under its chi route pattern, e.g.
+// /temperature/{zipCode}, rather than the raw path.
+func (m *HTTPMetrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		route := unmatchedRoute
+		if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+			route = routeContext.RoutePattern()
+		}
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		m.Observe(methodLabel(r.Method), route, status, time.Since(start))
+	})
+}
+
+func methodLabel(method string) string {
+	if knownMethods[method] {
+		return method
+	}
+	return otherMethod
+}
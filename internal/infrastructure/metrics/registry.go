@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the latency buckets, in seconds, used by the HTTP and
+// upstream histograms. They match the Prometheus client defaults.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type family interface {
+	name() string
+	write(w *bufio.Writer)
+}
+
+// Registry holds counters and histograms and renders them in the Prometheus
+// text exposition format (version 0.0.4). It is concurrency-safe.
+type Registry struct {
+	mu       sync.Mutex
+	families []family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.families {
+		if existing.name() == f.name() {
+			panic(fmt.Sprintf("metrics: %s registered twice", f.name()))
+		}
+	}
+	r.families = append(r.families, f)
+}
+
+// WriteText writes every metric family, sorted by name, with its series
+// sorted by label values so the output is stable.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	families := append([]family(nil), r.families...)
+	r.mu.Unlock()
+
+	sort.Slice(families, func(i, j int) bool { return families[i].name() < families[j].name() })
+
+	buffered := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(buffered)
+	}
+	return buffered.Flush()
+}
+
+type desc struct {
+	metricName string
+	help       string
+	labels     []string
+}
+
+func (d desc) name() string {
+	return d.metricName
+}
+
+func (d desc) writeHeader(w *bufio.Writer, metricType string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.metricName, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.metricName, metricType)
+}
+
+// seriesKey joins label values with a byte that cannot appear in valid UTF-8,
+// so distinct value lists never collide.
+func (d desc) seriesKey(labelValues []string) string {
+	if len(labelValues) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.metricName, len(d.labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+// labelPairs renders {a="1",b="2"}; extra holds name, value pairs appended
+// last, which is how histograms add le.
+func (d desc) labelPairs(labelValues []string, extra ...string) string {
+	pairs := make([]string, 0, len(d.labels)+len(extra)/2)
+	for i, label := range d.labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label, escapeLabelValue(labelValues[i])))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[i], escapeLabelValue(extra[i+1])))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
@@ -0,0 +1,129 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/metrics"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeText(t *testing.T, registry *metrics.Registry) string {
+	var out strings.Builder
+	require.NoError(t, registry.WriteText(&out))
+	return out.String()
+}
+
+func TestRegistry_WriteText(t *testing.T) {
+	t.Run("should render counters and histograms sorted by name and labels", func(t *testing.T) {
+		registry := metrics.NewRegistry()
+		latency := registry.NewHistogramVec("b_latency_seconds", "Latency.", []float64{1, 0.5}, "op")
+		counter := registry.NewCounterVec("a_total", "Things counted.", "kind")
+		counter.Inc("y")
+		counter.Add(2, "x")
+		latency.Observe(0.5, "get")
+		latency.Observe(0.7, "get")
+		latency.Observe(3, "get")
+
+		assert.Equal(t, `# HELP a_total Things counted.
+# TYPE a_total counter
+a_total{kind="x"} 2
+a_total{kind="y"} 1
+# HELP b_latency_seconds Latency.
+# TYPE b_latency_seconds histogram
+b_latency_seconds_bucket{op="get",le="0.5"} 1
+b_latency_seconds_bucket{op="get",le="1"} 2
+b_latency_seconds_bucket{op="get",le="+Inf"} 3
+b_latency_seconds_sum{op="get"} 4.2
+b_latency_seconds_count{op="get"} 3
+`, writeText(t, registry))
+	})
+
+	t.Run("should escape label values and help text", func(t *testing.T) {
+		registry := metrics.NewRegistry()
+		registry.NewCounterVec("escaped_total", "Line one\nback\\slash.", "value").Inc("a \"quoted\"\\\nvalue")
+
+		out := writeText(t, registry)
+
+		assert.Contains(t, out, `# HELP escaped_total Line one\nback\\slash.`)
+		assert.Contains(t, out, `escaped_total{value="a \"quoted\"\\\nvalue"} 1`)
+	})
+
+	t.Run("should panic on a duplicate name or a wrong number of label values", func(t *testing.T) {
+		registry := metrics.NewRegistry()
+		counter := registry.NewCounterVec("dup_total", "Dup.", "a")
+
+		assert.Panics(t, func() { registry.NewCounterVec("dup_total", "Dup.") })
+		assert.Panics(t, func() { counter.Inc("a", "b") })
+		assert.Panics(t, func() { counter.Add(-1, "a") })
+	})
+
+	t.Run("should be safe for concurrent use", func(t *testing.T) {
+		registry := metrics.NewRegistry()
+		counter := registry.NewCounterVec("concurrent_total", "Concurrent.", "worker")
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					counter.Inc("w")
+					_ = writeText(t, registry)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Contains(t, writeText(t, registry), `concurrent_total{worker="w"} 800`)
+	})
+}
+
+func TestHTTPMetrics_Middleware(t *testing.T) {
+	registry := metrics.NewRegistry()
+	httpMetrics := metrics.NewHTTPMetrics(registry)
+	router := chi.NewRouter()
+	router.Use(httpMetrics.Middleware)
+	router.Get("/temperature/{zipCode}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	router.Get("/status", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	for _, path := range []string{"/temperature/12345678", "/temperature/87654321", "/status", "/nowhere"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	for _, method := range []string{"FOO", "BAR"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/nowhere", nil))
+	}
+
+	out := writeText(t, registry)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/temperature/{zipCode}",status="404"} 2`)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/status",status="200"} 1`)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, out, `http_request_duration_seconds_count{method="GET",route="/status",status="200"} 1`)
+	assert.Contains(t, out, `http_requests_total{method="other",route="unmatched",status="405"} 2`)
+	assert.NotContains(t, out, "12345678")
+	assert.NotContains(t, out, "FOO")
+}
+
+func TestUpstreamMetrics_Observe(t *testing.T) {
+	registry := metrics.NewRegistry()
+	upstreamMetrics := metrics.NewUpstreamMetrics(registry)
+
+	upstreamMetrics.Observe("weather", 200, metrics.ErrorClassNone, 30*time.Millisecond)
+	upstreamMetrics.Observe("weather", 0, metrics.ErrorClassTimeout, 3*time.Second)
+
+	out := writeText(t, registry)
+	assert.Contains(t, out, `upstream_requests_total{upstream="weather",status="0"} 1`)
+	assert.Contains(t, out, `upstream_requests_total{upstream="weather",status="200"} 1`)
+	assert.Contains(t, out, `upstream_errors_total{upstream="weather",class="timeout"} 1`)
+	assert.Contains(t, out, `upstream_request_duration_seconds_bucket{upstream="weather",le="2.5"} 1`)
+	assert.Contains(t, out, `upstream_request_duration_seconds_count{upstream="weather"} 2`)
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+)
+
+// Error classes of upstream calls. A call that got a response is classified
+// by its status; one that did not, by why.
+const (
+	ErrorClassNone        = ""
+	ErrorClassTimeout     = "timeout"
+	ErrorClassCanceled    = "canceled"
+	ErrorClassCircuitOpen = "circuit_open"
+	ErrorClassNetwork     = "network"
+	ErrorClassClientError = "client_error"
+	ErrorClassServerError = "server_error"
+)
+
+type UpstreamMetrics struct {
+	requests *CounterVec
+	errors   *CounterVec
+	duration *HistogramVec
+}
+
+func NewUpstreamMetrics(registry *Registry) *UpstreamMetrics {
+	return &UpstreamMetrics{
+		requests: registry.NewCounterVec("upstream_requests_total",
+			"Calls to upstream APIs, by upstream and response status (0 when none was received).", "upstream", "status"),
+		errors: registry.NewCounterVec("upstream_errors_total",
+			"Failed calls to upstream APIs, by upstream and error class.", "upstream", "class"),
+		duration: registry.NewHistogramVec("upstream_request_duration_seconds",
+			"Upstream call latency, retries included, by upstream.", DefaultBuckets, "upstream"),
+	}
+}
+
+// Observe records one call. status is 0 when no response was received.
+func (m *UpstreamMetrics) Observe(upstream string, status int, errorClass string, duration time.Duration) {
+	m.requests.Inc(upstream, strconv.Itoa(status))
+	m.duration.Observe(duration.Seconds(), upstream)
+	if errorClass != ErrorClassNone {
+		m.errors.Inc(upstream, errorClass)
+	}
+}
@@ -0,0 +1,53 @@
+package redact
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+)
+
+// Placeholder replaces secret values.
+const Placeholder = "REDACTED"
+
+// secretParam matches the value of query parameters that carry API keys, such
+// as WeatherAPI's key and OpenWeatherMap's appid, wherever a URL appears in a
+// text: Go's HTTP client quotes the full URL in its errors.
+var secretParam = regexp.MustCompile(`(?i)([?&](?:key|appid|api_key|apikey|access_token|token)=)[^&#\s"']*`)
+
+// String hides the secret query parameters of every URL in s.
+func String(s string) string {
+	return secretParam.ReplaceAllString(s, "${1}"+Placeholder)
+}
+
+// Error returns err with the secrets hidden from its message. errors.Is/As
+// still reach the original error, and a *url.Error stays one so timeouts are
+// still detected.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	redacted := String(msg)
+	if redacted == msg {
+		return err
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr == err {
+		return &url.Error{Op: urlErr.Op, URL: String(urlErr.URL), Err: Error(urlErr.Err)}
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
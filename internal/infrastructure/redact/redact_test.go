@@ -0,0 +1,62 @@
+package redact_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString(t *testing.T) {
+	t.Run("Should hide API keys When the text quotes a URL", func(t *testing.T) {
+		s := `Get "https://api.weatherapi.com/v1/current.json?key=s3cr3t&q=-23.5,-46.6": EOF`
+		assert.Equal(t, `Get "https://api.weatherapi.com/v1/current.json?key=REDACTED&q=-23.5,-46.6": EOF`, redact.String(s))
+	})
+
+	t.Run("Should hide every secret parameter When there are several", func(t *testing.T) {
+		s := "https://a.example/x?q=1&appid=abc https://b.example/y?API_KEY=def#frag"
+		assert.Equal(t, "https://a.example/x?q=1&appid=REDACTED https://b.example/y?API_KEY=REDACTED#frag", redact.String(s))
+	})
+
+	t.Run("Should keep the text When it has no secret", func(t *testing.T) {
+		s := "https://viacep.com.br/ws/01001000/json?monkey=1"
+		assert.Equal(t, s, redact.String(s))
+	})
+}
+
+func TestError(t *testing.T) {
+	t.Run("Should return nil When err is nil", func(t *testing.T) {
+		assert.NoError(t, redact.Error(nil))
+	})
+
+	t.Run("Should return err itself When it has no secret", func(t *testing.T) {
+		err := errors.New("connection refused")
+		assert.Same(t, err, redact.Error(err))
+	})
+
+	t.Run("Should keep a url.Error When its URL has a secret", func(t *testing.T) {
+		err := &url.Error{Op: "Get", URL: "https://api.weatherapi.com/v1/current.json?key=s3cr3t", Err: context.DeadlineExceeded}
+
+		redacted := redact.Error(err)
+
+		var urlErr *url.Error
+		assert.ErrorAs(t, redacted, &urlErr)
+		assert.NotContains(t, redacted.Error(), "s3cr3t")
+		assert.True(t, urlErr.Timeout())
+		assert.ErrorIs(t, redacted, context.DeadlineExceeded)
+	})
+
+	t.Run("Should hide the secret When a wrapped error quotes it", func(t *testing.T) {
+		cause := &url.Error{Op: "Get", URL: "https://api.openweathermap.org/data/2.5/weather?appid=s3cr3t", Err: errors.New("EOF")}
+		err := fmt.Errorf("attempt 3: %w", cause)
+
+		redacted := redact.Error(err)
+
+		assert.Equal(t, `attempt 3: Get "https://api.openweathermap.org/data/2.5/weather?appid=REDACTED": EOF`, redacted.Error())
+		assert.ErrorIs(t, redacted, cause)
+	})
+}
@@ -0,0 +1,60 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Header carries the request ID in both directions: read from and echoed to
+// clients, and forwarded to upstreams.
+const Header = "X-Request-ID"
+
+// cloudTraceHeader is set by Cloud Run's front end as TRACE_ID/SPAN_ID;o=1.
+const cloudTraceHeader = "X-Cloud-Trace-Context"
+
+// validID bounds what is accepted from clients, since the ID ends up in logs,
+// headers and response bodies.
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:+=/-]{1,128}$`)
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID, or "" outside a request.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Middleware takes the request ID from X-Request-ID, falls back to the trace
+// ID of X-Cloud-Trace-Context and otherwise generates one. The ID is stored in
+// the request context and echoed in the response header.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := fromHeaders(r.Header)
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+func fromHeaders(header http.Header) string {
+	if id := strings.TrimSpace(header.Get(Header)); validID.MatchString(id) {
+		return id
+	}
+	if traceID, _, _ := strings.Cut(header.Get(cloudTraceHeader), "/"); validID.MatchString(traceID) {
+		return traceID
+	}
+	return generate()
+}
+
+func generate() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,62 @@
+package requestid_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(header http.Header) (string, string) {
+	var fromContext string
+	handler := requestid.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fromContext = requestid.FromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/temperature/12345678", nil)
+	req.Header = header
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return fromContext, w.Header().Get(requestid.Header)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("Should keep the client request ID When it is valid", func(t *testing.T) {
+		fromContext, echoed := serve(http.Header{"X-Request-Id": {"client-42"}})
+		assert.Equal(t, "client-42", fromContext)
+		assert.Equal(t, "client-42", echoed)
+	})
+
+	t.Run("Should use the Cloud Run trace ID When there is no request ID", func(t *testing.T) {
+		fromContext, echoed := serve(http.Header{"X-Cloud-Trace-Context": {"105445aa7843bc8bf206b12000100000/1;o=1"}})
+		assert.Equal(t, "105445aa7843bc8bf206b12000100000", fromContext)
+		assert.Equal(t, fromContext, echoed)
+	})
+
+	t.Run("Should generate an ID When the client one is missing or unsafe", func(t *testing.T) {
+		for _, header := range []http.Header{
+			{},
+			{"X-Request-Id": {"bad id\r\nSet-Cookie: x"}},
+			{"X-Request-Id": {strings.Repeat("a", 129)}},
+		} {
+			fromContext, echoed := serve(header)
+			assert.Regexp(t, `^[0-9a-f]{32}$`, fromContext)
+			assert.Equal(t, fromContext, echoed)
+		}
+	})
+
+	t.Run("Should generate distinct IDs", func(t *testing.T) {
+		first, _ := serve(http.Header{})
+		second, _ := serve(http.Header{})
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("Should return empty When outside a request", func(t *testing.T) {
+		assert.Empty(t, requestid.FromContext(context.Background()))
+	})
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/dto"
+)
+
+type brasilApiCepService struct {
+	settings EndpointSettings
+	client   config.HTTPDoer
+}
+
+func NewBrasilApiCepService(client config.HTTPDoer, settings EndpointSettings) gateway.ViaCepService {
+	if client == nil {
+		client = config.NewHTTPClient(3 * time.Second)
+	}
+	return &brasilApiCepService{
+		settings: settings,
+		client:   client,
+	}
+}
+
+func (s *brasilApiCepService) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError) {
+	ep := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.Path, zipCodeDigits(zipCode))
+
+	url, err := ep.Build()
+	if err != nil {
+		return nil, endpointError(err)
+	}
+
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "brasil api")
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		return nil, model.NewInvalidZipCodeError()
+	case http.StatusNotFound:
+		return nil, model.NewZipCodeNotFoundError()
+	default:
+		return nil, unexpectedZipCodeStatusError(statusCode, "brasil api", body)
+	}
+
+	var brasilApiDto dto.BrasilApiCepDto
+	if err := json.Unmarshal(body, &brasilApiDto); err != nil {
+		return nil, unmarshalError("brasil api", err)
+	}
+
+	if brasilApiDto.City == "" {
+		return nil, emptyCityError("brasil api")
+	}
+
+	return &model.Address{
+		ZipCode:      brasilApiDto.ZipCode,
+		Street:       brasilApiDto.Street,
+		Neighborhood: brasilApiDto.Neighborhood,
+		City:         brasilApiDto.City,
+		State:        brasilApiDto.State,
+		Coordinates:  brasilApiCoordinates(brasilApiDto),
+	}, nil
+}
+
+// brasilApiCoordinates returns the geolocation BrasilAPI sometimes attaches to
+// a zip code, or nil when it is missing or not numeric.
+func brasilApiCoordinates(brasilApiDto dto.BrasilApiCepDto) *model.Coordinates {
+	latitude, err := strconv.ParseFloat(brasilApiDto.Location.Coordinates.Latitude, 64)
+	if err != nil {
+		return nil
+	}
+	longitude, err := strconv.ParseFloat(brasilApiDto.Location.Coordinates.Longitude, 64)
+	if err != nil {
+		return nil
+	}
+	return &model.Coordinates{Latitude: latitude, Longitude: longitude}
+}
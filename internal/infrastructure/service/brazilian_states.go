@@ -0,0 +1,72 @@
+package service
+
+import "strings"
+
+// brazilianStates maps each UF to the names providers use for the state. The
+// first name is the one sent in queries; the others are accepted as matches
+// (WeatherAPI, for instance, reports "Federal District" for DF).
+var brazilianStates = map[string][]string{
+	"AC": {"Acre"},
+	"AL": {"Alagoas"},
+	"AP": {"Amapá"},
+	"AM": {"Amazonas"},
+	"BA": {"Bahia"},
+	"CE": {"Ceará"},
+	"DF": {"Distrito Federal", "Federal District"},
+	"ES": {"Espírito Santo"},
+	"GO": {"Goiás"},
+	"MA": {"Maranhão"},
+	"MT": {"Mato Grosso"},
+	"MS": {"Mato Grosso do Sul"},
+	"MG": {"Minas Gerais"},
+	"PA": {"Pará"},
+	"PB": {"Paraíba"},
+	"PR": {"Paraná"},
+	"PE": {"Pernambuco"},
+	"PI": {"Piauí"},
+	"RJ": {"Rio de Janeiro"},
+	"RN": {"Rio Grande do Norte"},
+	"RS": {"Rio Grande do Sul"},
+	"RO": {"Rondônia"},
+	"RR": {"Roraima"},
+	"SC": {"Santa Catarina"},
+	"SP": {"São Paulo"},
+	"SE": {"Sergipe"},
+	"TO": {"Tocantins"},
+}
+
+// stateName returns the full name of the state with the given UF, or "" when
+// the UF is unknown.
+func stateName(uf string) string {
+	names, ok := brazilianStates[strings.ToUpper(strings.TrimSpace(uf))]
+	if !ok {
+		return ""
+	}
+	return names[0]
+}
+
+// regionMatchesState reports whether region, as returned by a weather
+// provider, names the state with the given UF. An unknown UF matches anything
+// since there is nothing to check against.
+func regionMatchesState(region, uf string) bool {
+	names, ok := brazilianStates[strings.ToUpper(strings.TrimSpace(uf))]
+	if !ok {
+		return true
+	}
+	normalizedRegion := normalizeName(region)
+	for _, name := range names {
+		if normalizeName(name) == normalizedRegion {
+			return true
+		}
+	}
+	return false
+}
+
+func isBrazil(country string) bool {
+	switch strings.ToLower(strings.TrimSpace(country)) {
+	case "brazil", "brasil", "br":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateName(t *testing.T) {
+	t.Run("should return state name for known uf", func(t *testing.T) {
+		assert.Equal(t, "Piauí", stateName("PI"))
+		assert.Equal(t, "Rio Grande do Sul", stateName(" rs "))
+	})
+
+	t.Run("should return empty string for unknown uf", func(t *testing.T) {
+		assert.Equal(t, "", stateName("XX"))
+		assert.Equal(t, "", stateName(""))
+	})
+}
+
+func TestRegionMatchesState(t *testing.T) {
+	t.Run("should match regardless of accents and case", func(t *testing.T) {
+		assert.True(t, regionMatchesState("Piaui", "PI"))
+		assert.True(t, regionMatchesState("SAO PAULO", "SP"))
+	})
+
+	t.Run("should match alternative state names", func(t *testing.T) {
+		assert.True(t, regionMatchesState("Federal District", "DF"))
+	})
+
+	t.Run("should not match a different state", func(t *testing.T) {
+		assert.False(t, regionMatchesState("Rio Grande do Sul", "PI"))
+	})
+
+	t.Run("should match anything when uf is unknown", func(t *testing.T) {
+		assert.True(t, regionMatchesState("Anywhere", ""))
+	})
+}
+
+func TestIsBrazil(t *testing.T) {
+	assert.True(t, isBrazil("Brazil"))
+	assert.True(t, isBrazil("Brasil"))
+	assert.True(t, isBrazil("BR"))
+	assert.False(t, isBrazil("Portugal"))
+}
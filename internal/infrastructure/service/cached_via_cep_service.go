@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/cache"
+)
+
+type CachedViaCepService interface {
+	gateway.ViaCepService
+	Stats() cache.Stats
+}
+
+type viaCepCacheEntry struct {
+	address model.Address
+	err     *model.CustomError
+}
+
+type cachedViaCepService struct {
+	next        gateway.ViaCepService
+	cache       *cache.LRU[string, viaCepCacheEntry]
+	ttl         time.Duration
+	notFoundTTL time.Duration
+}
+
+// NewCachedViaCepService decorates next with an LRU cache. Resolved addresses are
+// kept for ttl and "can not find zipcode" answers for notFoundTTL; any other
+// error is never cached.
+func NewCachedViaCepService(next gateway.ViaCepService, size int, ttl, notFoundTTL time.Duration) CachedViaCepService {
+	return &cachedViaCepService{
+		next:        next,
+		cache:       cache.NewLRU[string, viaCepCacheEntry](size),
+		ttl:         ttl,
+		notFoundTTL: notFoundTTL,
+	}
+}
+
+func (s *cachedViaCepService) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError) {
+	key := zipCodeDigits(zipCode)
+
+	if cached, ok := s.cache.Get(key); ok {
+		if cached.err != nil {
+			return nil, cached.err
+		}
+		address := cached.address
+		return &address, nil
+	}
+
+	address, err := s.next.GetAddressByZipCode(ctx, zipCode)
+	if err != nil {
+		if err.StatusCode == http.StatusNotFound {
+			s.cache.Set(key, viaCepCacheEntry{err: err}, s.notFoundTTL)
+		}
+		return nil, err
+	}
+
+	s.cache.Set(key, viaCepCacheEntry{address: *address}, s.ttl)
+	return address, nil
+}
+
+func (s *cachedViaCepService) Stats() cache.Stats {
+	return s.cache.Stats()
+}
@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCachedViaCepService_GetAddressByZipCode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should call upstream once and serve next lookups from cache", func(t *testing.T) {
+		address := &model.Address{ZipCode: "90040-000", City: "Porto Alegre", State: "RS"}
+		next := serviceMock.NewMockViaCepService(t)
+		next.On("GetAddressByZipCode", mock.Anything, model.ZipCode("90040-000")).Return(address, nil).Once()
+
+		svc := servicepkg.NewCachedViaCepService(next, 10, time.Hour, time.Minute)
+		first, err := svc.GetAddressByZipCode(ctx, "90040-000")
+		assert.Nil(t, err)
+		second, err := svc.GetAddressByZipCode(ctx, "90040000")
+		assert.Nil(t, err)
+
+		assert.Equal(t, *address, *first)
+		assert.Equal(t, *address, *second)
+		assert.Equal(t, uint64(1), svc.Stats().Hits)
+		assert.Equal(t, uint64(1), svc.Stats().Misses)
+	})
+
+	t.Run("should cache not found errors", func(t *testing.T) {
+		next := serviceMock.NewMockViaCepService(t)
+		next.On("GetAddressByZipCode", mock.Anything, model.ZipCode("90040999")).
+			Return(nil, model.NewCustomError(http.StatusNotFound, "can not find zipcode")).Once()
+
+		svc := servicepkg.NewCachedViaCepService(next, 10, time.Hour, time.Minute)
+		_, err := svc.GetAddressByZipCode(ctx, "90040999")
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		_, err = svc.GetAddressByZipCode(ctx, "90040999")
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		assert.Equal(t, "can not find zipcode", err.Error())
+	})
+
+	t.Run("should not cache not found errors when not found ttl is zero", func(t *testing.T) {
+		next := serviceMock.NewMockViaCepService(t)
+		next.On("GetAddressByZipCode", mock.Anything, model.ZipCode("90040999")).
+			Return(nil, model.NewCustomError(http.StatusNotFound, "can not find zipcode")).Twice()
+
+		svc := servicepkg.NewCachedViaCepService(next, 10, time.Hour, 0)
+		_, _ = svc.GetAddressByZipCode(ctx, "90040999")
+		_, err := svc.GetAddressByZipCode(ctx, "90040999")
+		assert.Equal(t, http.StatusNotFound, err.StatusCode)
+	})
+
+	t.Run("should not cache other errors", func(t *testing.T) {
+		next := serviceMock.NewMockViaCepService(t)
+		next.On("GetAddressByZipCode", mock.Anything, model.ZipCode("90040000")).
+			Return(nil, model.NewCustomError(http.StatusInternalServerError, "error sending request")).Twice()
+
+		svc := servicepkg.NewCachedViaCepService(next, 10, time.Hour, time.Minute)
+		_, _ = svc.GetAddressByZipCode(ctx, "90040000")
+		_, err := svc.GetAddressByZipCode(ctx, "90040000")
+		assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		assert.Equal(t, 0, svc.Stats().Size)
+	})
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/cache"
+	"golang.org/x/sync/singleflight"
+)
+
+type CachedWeatherService interface {
+	gateway.WeatherService
+	Stats() cache.Stats
+}
+
+type weatherLookup struct {
+	weather model.Weather
+	err     *model.CustomError
+}
+
+type cachedWeatherService struct {
+	next          gateway.WeatherService
+	cache         *cache.LRU[string, model.Weather]
+	ttl           time.Duration
+	flightTimeout time.Duration
+	group         singleflight.Group
+}
+
+// NewCachedWeatherService decorates next with a short-lived cache keyed by the
+// normalized city name and state. Concurrent lookups for the same city share
+// a single upstream call, bounded by flightTimeout; errors are never cached.
+func NewCachedWeatherService(next gateway.WeatherService, size int, ttl, flightTimeout time.Duration) CachedWeatherService {
+	return &cachedWeatherService{
+		next:          next,
+		cache:         cache.NewLRU[string, model.Weather](size),
+		ttl:           ttl,
+		flightTimeout: flightTimeout,
+	}
+}
+
+func (s *cachedWeatherService) GetWeatherByCity(ctx context.Context, city model.City) (*model.Weather, *model.CustomError) {
+	key := weatherCacheKey(city)
+
+	if weather, ok := s.cache.Get(key); ok {
+		return &weather, nil
+	}
+
+	result := s.group.DoChan(key, func() (interface{}, error) {
+		// The shared call must not be aborted because the caller that happened
+		// to start it went away, so it runs detached from that caller's
+		// cancellation, under a deadline of its own as the caller's is gone too.
+		sharedCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.flightTimeout)
+		defer cancel()
+
+		// A caller that missed the cache just as the previous flight ended
+		// starts a new one; it must not call the upstream again.
+		if weather, ok := s.cache.Peek(key); ok {
+			return weatherLookup{weather: weather}, nil
+		}
+		weather, err := s.next.GetWeatherByCity(sharedCtx, city)
+		if err != nil {
+			return weatherLookup{err: err}, nil
+		}
+		s.cache.Set(key, *weather, s.ttl)
+		return weatherLookup{weather: *weather}, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, model.NewCodedError(model.ErrorCodeUpstreamTimeout, http.StatusGatewayTimeout,
+			"request cancelled while waiting for weather api").WithCause(ctx.Err())
+	case res := <-result:
+		lookup := res.Val.(weatherLookup)
+		if lookup.err != nil {
+			return nil, lookup.err
+		}
+		return &lookup.weather, nil
+	}
+}
+
+// GetConditionsByCity is not cached: only the temperature is looked up often
+// enough to be worth it.
+func (s *cachedWeatherService) GetConditionsByCity(ctx context.Context, city model.City) (*model.WeatherConditions, *model.CustomError) {
+	return s.next.GetConditionsByCity(ctx, city)
+}
+
+// GetForecastByCity is not cached: forecasts are requested far less often
+// than current conditions and change with every model run.
+func (s *cachedWeatherService) GetForecastByCity(ctx context.Context, city model.City, days int) (*model.WeatherForecast, *model.CustomError) {
+	return s.next.GetForecastByCity(ctx, city, days)
+}
+
+// GetHistoryByCity is not cached: history lookups are rare.
+func (s *cachedWeatherService) GetHistoryByCity(ctx context.Context, city model.City, date time.Time) (*model.WeatherForecast, *model.CustomError) {
+	return s.next.GetHistoryByCity(ctx, city, date)
+}
+
+// GetAirQualityByCity is not cached: alerts must not be served stale.
+func (s *cachedWeatherService) GetAirQualityByCity(ctx context.Context, city model.City) (*model.AirQualityReport, *model.CustomError) {
+	return s.next.GetAirQualityByCity(ctx, city)
+}
+
+func (s *cachedWeatherService) Stats() cache.Stats {
+	return s.cache.Stats()
+}
+
+// weatherCacheKey keys lookups by normalized city and UF, so homonyms in
+// different states are cached apart. Lookups by coordinates alone are keyed
+// by the coordinates rounded to about a hundred meters.
+func weatherCacheKey(city model.City) string {
+	if strings.TrimSpace(city.Name) == "" && city.Coordinates != nil {
+		return fmt.Sprintf("%.3f,%.3f", city.Coordinates.Latitude, city.Coordinates.Longitude)
+	}
+	return normalizeName(city.Name) + "/" + strings.ToUpper(strings.TrimSpace(city.State))
+}
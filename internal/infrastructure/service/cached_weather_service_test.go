@@ -0,0 +1,167 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedWeatherService_GetWeatherByCity(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should serve accent and case variations of a city from cache", func(t *testing.T) {
+		weather := &model.Weather{Celsius: 21.5}
+		next := serviceMock.NewMockWeatherService(t)
+		next.On("GetWeatherByCity", mock.Anything, model.City{Name: "São Paulo", State: "SP"}).Return(weather, nil).Once()
+
+		svc := servicepkg.NewCachedWeatherService(next, 10, time.Minute, time.Second)
+		first, err := svc.GetWeatherByCity(ctx, model.City{Name: "São Paulo", State: "SP"})
+		assert.Nil(t, err)
+		second, err := svc.GetWeatherByCity(ctx, model.City{Name: "sao  PAULO", State: "SP"})
+		assert.Nil(t, err)
+
+		assert.InDelta(t, 21.5, first.Celsius, 0.01)
+		assert.InDelta(t, 21.5, second.Celsius, 0.01)
+		assert.Equal(t, uint64(1), svc.Stats().Hits)
+	})
+
+	t.Run("should not cache errors", func(t *testing.T) {
+		next := serviceMock.NewMockWeatherService(t)
+		next.On("GetWeatherByCity", mock.Anything, model.City{Name: "Porto Alegre", State: "RS"}).
+			Return(nil, model.NewCustomError(http.StatusInternalServerError, "weather error")).Twice()
+
+		svc := servicepkg.NewCachedWeatherService(next, 10, time.Minute, time.Second)
+		_, _ = svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "weather error", err.Error())
+	})
+
+	t.Run("should share a single upstream call between concurrent lookups", func(t *testing.T) {
+		weather := &model.Weather{Celsius: 18.0}
+		entered := make(chan struct{})
+		release := make(chan struct{})
+		next := serviceMock.NewMockWeatherService(t)
+		next.On("GetWeatherByCity", mock.Anything, model.City{Name: "Porto Alegre", State: "RS"}).
+			Run(func(mock.Arguments) {
+				close(entered)
+				<-release
+			}).
+			Return(weather, nil).Once()
+
+		svc := servicepkg.NewCachedWeatherService(next, 10, time.Minute, time.Second)
+
+		const callers = 10
+		var wg sync.WaitGroup
+		results := make([]*model.Weather, callers)
+		for i := range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _ = svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+			}()
+		}
+
+		// Every caller has missed the cache while the upstream call is held,
+		// so each one either joins that call or, if it arrives after it ended,
+		// finds the value the call cached.
+		<-entered
+		require.Eventually(t, func() bool { return svc.Stats().Misses == callers }, 5*time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		for _, result := range results {
+			if assert.NotNil(t, result) {
+				assert.InDelta(t, 18.0, result.Celsius, 0.01)
+			}
+		}
+	})
+
+	t.Run("should return when caller context is cancelled", func(t *testing.T) {
+		weather := &model.Weather{Celsius: 18.0}
+		release := make(chan struct{})
+		defer close(release)
+		next := serviceMock.NewMockWeatherService(t)
+		next.On("GetWeatherByCity", mock.Anything, model.City{Name: "Pelotas", State: "RS"}).
+			Run(func(mock.Arguments) { <-release }).
+			Return(weather, nil).Maybe()
+
+		svc := servicepkg.NewCachedWeatherService(next, 10, time.Minute, time.Second)
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		_, err := svc.GetWeatherByCity(cancelledCtx, model.City{Name: "Pelotas", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusGatewayTimeout, err.StatusCode)
+		}
+	})
+
+	t.Run("should end the shared call when the upstream hangs", func(t *testing.T) {
+		city := model.City{Name: "Canoas", State: "RS"}
+		next := serviceMock.NewMockWeatherService(t)
+		next.On("GetWeatherByCity", mock.Anything, city).
+			Return(func(ctx context.Context, _ model.City) (*model.Weather, *model.CustomError) {
+				<-ctx.Done()
+				return nil, model.NewCustomError(http.StatusGatewayTimeout, ctx.Err().Error())
+			}, nil).Twice()
+
+		svc := servicepkg.NewCachedWeatherService(next, 10, time.Minute, 20*time.Millisecond)
+		for range 2 {
+			_, err := svc.GetWeatherByCity(ctx, city)
+			if assert.NotNil(t, err) {
+				assert.Equal(t, http.StatusGatewayTimeout, err.StatusCode)
+				assert.Equal(t, context.DeadlineExceeded.Error(), err.Error())
+			}
+		}
+	})
+
+	t.Run("should cache homonyms in different states apart", func(t *testing.T) {
+		piaui := model.City{Name: "Bom Jesus", State: "PI"}
+		rioGrandeDoSul := model.City{Name: "Bom Jesus", State: "RS"}
+		next := serviceMock.NewMockWeatherService(t)
+		next.On("GetWeatherByCity", mock.Anything, piaui).Return(&model.Weather{Celsius: 31.0}, nil).Once()
+		next.On("GetWeatherByCity", mock.Anything, rioGrandeDoSul).Return(&model.Weather{Celsius: 12.0}, nil).Once()
+
+		svc := servicepkg.NewCachedWeatherService(next, 10, time.Minute, time.Second)
+		first, err := svc.GetWeatherByCity(ctx, piaui)
+		assert.Nil(t, err)
+		second, err := svc.GetWeatherByCity(ctx, rioGrandeDoSul)
+		assert.Nil(t, err)
+
+		assert.InDelta(t, 31.0, first.Celsius, 0.01)
+		assert.InDelta(t, 12.0, second.Celsius, 0.01)
+		assert.Equal(t, uint64(0), svc.Stats().Hits)
+	})
+
+	t.Run("should cache lookups by coordinates apart", func(t *testing.T) {
+		portoAlegre := model.City{Coordinates: &model.Coordinates{Latitude: -30.03, Longitude: -51.2}}
+		saoPaulo := model.City{Coordinates: &model.Coordinates{Latitude: -23.55, Longitude: -46.63}}
+		next := serviceMock.NewMockWeatherService(t)
+		next.On("GetWeatherByCity", mock.Anything, portoAlegre).Return(&model.Weather{Celsius: 12.0}, nil).Once()
+		next.On("GetWeatherByCity", mock.Anything, saoPaulo).Return(&model.Weather{Celsius: 22.0}, nil).Once()
+
+		svc := servicepkg.NewCachedWeatherService(next, 10, time.Minute, time.Second)
+		first, err := svc.GetWeatherByCity(ctx, portoAlegre)
+		assert.Nil(t, err)
+		second, err := svc.GetWeatherByCity(ctx, saoPaulo)
+		assert.Nil(t, err)
+		third, err := svc.GetWeatherByCity(ctx, portoAlegre)
+		assert.Nil(t, err)
+
+		assert.InDelta(t, 12.0, first.Celsius, 0.01)
+		assert.InDelta(t, 22.0, second.Celsius, 0.01)
+		assert.InDelta(t, 12.0, third.Celsius, 0.01)
+		assert.Equal(t, uint64(1), svc.Stats().Hits)
+	})
+}
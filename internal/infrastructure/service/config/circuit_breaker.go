@@ -0,0 +1,247 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type CircuitState int
+
+const (
+	StateClosed CircuitState = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitBreakerSettings struct {
+	// ConsecutiveFailures trips the breaker after that many failures in a row.
+	ConsecutiveFailures int
+	// FailureRatio trips the breaker when failures/requests reaches it, once
+	// at least MinRequests were seen in the current Interval.
+	FailureRatio float64
+	MinRequests  int
+	// Interval is how often the closed-state counters are reset.
+	Interval time.Duration
+	// CoolDown is how long the breaker stays open before letting probes through.
+	CoolDown time.Duration
+	// HalfOpenMaxRequests is how many concurrent probes are allowed while half-open.
+	HalfOpenMaxRequests int
+}
+
+type CircuitBreakerStatus struct {
+	State               string     `json:"state"`
+	Requests            int        `json:"requests"`
+	Failures            int        `json:"failures"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+}
+
+type CircuitBreaker interface {
+	HTTPDoer
+	State() CircuitState
+	Status() CircuitBreakerStatus
+}
+
+type circuitBreaker struct {
+	next     HTTPDoer
+	settings CircuitBreakerSettings
+	now      func() time.Time
+
+	mu                  sync.Mutex
+	state               CircuitState
+	generation          uint64
+	windowStart         time.Time
+	openedAt            time.Time
+	requests            int
+	failures            int
+	consecutiveFailures int
+	halfOpenInFlight    int
+}
+
+// NewCircuitBreakerHTTPDoer guards next with a closed/open/half-open circuit
+// breaker. Network errors and 5xx responses count as failures; while the
+// breaker is open, Do fails fast with ErrCircuitOpen.
+func NewCircuitBreakerHTTPDoer(next HTTPDoer, settings CircuitBreakerSettings) CircuitBreaker {
+	if settings.HalfOpenMaxRequests <= 0 {
+		settings.HalfOpenMaxRequests = 1
+	}
+
+	cb := &circuitBreaker{
+		next:     next,
+		settings: settings,
+		now:      time.Now,
+	}
+	cb.windowStart = cb.now()
+	return cb
+}
+
+func (cb *circuitBreaker) Do(req *http.Request) (*http.Response, error) {
+	generation, err := cb.beforeRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := cb.next.Do(req)
+	cb.afterRequest(generation, classifyCircuitOutcome(req, resp, err))
+	return resp, err
+}
+
+func (cb *circuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refreshState(cb.now())
+	return cb.state
+}
+
+func (cb *circuitBreaker) Status() CircuitBreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refreshState(cb.now())
+	status := CircuitBreakerStatus{
+		State:               cb.state.String(),
+		Requests:            cb.requests,
+		Failures:            cb.failures,
+		ConsecutiveFailures: cb.consecutiveFailures,
+	}
+	if cb.state != StateClosed {
+		openedAt := cb.openedAt
+		status.OpenedAt = &openedAt
+	}
+	return status
+}
+
+func (cb *circuitBreaker) beforeRequest() (uint64, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refreshState(cb.now())
+	switch cb.state {
+	case StateOpen:
+		return cb.generation, ErrCircuitOpen
+	case StateHalfOpen:
+		if cb.halfOpenInFlight >= cb.settings.HalfOpenMaxRequests {
+			return cb.generation, ErrCircuitOpen
+		}
+		cb.halfOpenInFlight++
+	}
+
+	cb.requests++
+	return cb.generation, nil
+}
+
+func (cb *circuitBreaker) afterRequest(generation uint64, outcome circuitOutcome) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	cb.refreshState(now)
+	if generation != cb.generation {
+		return
+	}
+
+	switch outcome {
+	case outcomeIgnored:
+		if cb.requests > 0 {
+			cb.requests--
+		}
+		if cb.state == StateHalfOpen {
+			cb.halfOpenInFlight--
+		}
+		return
+	case outcomeSuccess:
+		cb.consecutiveFailures = 0
+		if cb.state == StateHalfOpen {
+			cb.setState(StateClosed, now)
+		}
+	case outcomeFailure:
+		cb.failures++
+		cb.consecutiveFailures++
+		if cb.state == StateHalfOpen || cb.shouldTrip() {
+			cb.setState(StateOpen, now)
+		}
+	}
+}
+
+func (cb *circuitBreaker) shouldTrip() bool {
+	if cb.settings.ConsecutiveFailures > 0 && cb.consecutiveFailures >= cb.settings.ConsecutiveFailures {
+		return true
+	}
+	if cb.settings.FailureRatio > 0 && cb.requests >= cb.settings.MinRequests && cb.requests > 0 {
+		return float64(cb.failures)/float64(cb.requests) >= cb.settings.FailureRatio
+	}
+	return false
+}
+
+// refreshState applies the time-based transitions: open becomes half-open
+// after the cool-down and closed-state counters are reset every interval.
+func (cb *circuitBreaker) refreshState(now time.Time) {
+	switch cb.state {
+	case StateOpen:
+		if !now.Before(cb.openedAt.Add(cb.settings.CoolDown)) {
+			cb.setState(StateHalfOpen, now)
+		}
+	case StateClosed:
+		if cb.settings.Interval > 0 && !now.Before(cb.windowStart.Add(cb.settings.Interval)) {
+			cb.resetCounters(now)
+		}
+	}
+}
+
+func (cb *circuitBreaker) setState(state CircuitState, now time.Time) {
+	if state == StateOpen {
+		cb.openedAt = now
+	}
+	cb.state = state
+	cb.generation++
+	cb.halfOpenInFlight = 0
+	cb.resetCounters(now)
+}
+
+func (cb *circuitBreaker) resetCounters(now time.Time) {
+	cb.windowStart = now
+	cb.requests = 0
+	cb.failures = 0
+	cb.consecutiveFailures = 0
+}
+
+type circuitOutcome int
+
+const (
+	outcomeSuccess circuitOutcome = iota
+	outcomeFailure
+	outcomeIgnored
+)
+
+func classifyCircuitOutcome(req *http.Request, resp *http.Response, err error) circuitOutcome {
+	if err != nil {
+		// The caller giving up says nothing about the upstream health.
+		if errors.Is(req.Context().Err(), context.Canceled) {
+			return outcomeIgnored
+		}
+		return outcomeFailure
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return outcomeFailure
+	}
+	return outcomeSuccess
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testCircuitSettings = CircuitBreakerSettings{
+	ConsecutiveFailures: 3,
+	FailureRatio:        0.5,
+	MinRequests:         4,
+	Interval:            time.Minute,
+	CoolDown:            30 * time.Second,
+	HalfOpenMaxRequests: 1,
+}
+
+func newTestCircuitBreaker(next HTTPDoer, settings CircuitBreakerSettings) (*circuitBreaker, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cb := NewCircuitBreakerHTTPDoer(next, settings).(*circuitBreaker)
+	cb.now = func() time.Time { return now }
+	cb.windowStart = now
+	return cb, &now
+}
+
+func TestCircuitBreaker_Do(t *testing.T) {
+	t.Run("should stay closed while requests succeed", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusOK, ""), nil).Times(5)
+		cb, _ := newTestCircuitBreaker(next, testCircuitSettings)
+
+		for range 5 {
+			_, err := cb.Do(newGetRequest(context.Background()))
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, StateClosed, cb.State())
+	})
+
+	t.Run("should open after consecutive failures and fail fast", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, errors.New("connection refused")).Times(3)
+		cb, _ := newTestCircuitBreaker(next, testCircuitSettings)
+
+		for range 3 {
+			_, _ = cb.Do(newGetRequest(context.Background()))
+		}
+		resp, err := cb.Do(newGetRequest(context.Background()))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, StateOpen, cb.State())
+		assert.Equal(t, "open", cb.Status().State)
+		assert.NotNil(t, cb.Status().OpenedAt)
+	})
+
+	t.Run("should open when failure ratio is reached", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusOK, ""), nil).Once()
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusBadGateway, ""), nil).Once()
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusOK, ""), nil).Once()
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusBadGateway, ""), nil).Once()
+		cb, _ := newTestCircuitBreaker(next, testCircuitSettings)
+
+		for range 4 {
+			_, _ = cb.Do(newGetRequest(context.Background()))
+		}
+
+		assert.Equal(t, StateOpen, cb.State())
+	})
+
+	t.Run("should not count 4xx responses as failures", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusNotFound, ""), nil).Times(5)
+		cb, _ := newTestCircuitBreaker(next, testCircuitSettings)
+
+		for range 5 {
+			_, _ = cb.Do(newGetRequest(context.Background()))
+		}
+
+		assert.Equal(t, StateClosed, cb.State())
+	})
+
+	t.Run("should reset counters after interval", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusInternalServerError, ""), nil).Times(4)
+		settings := testCircuitSettings
+		settings.FailureRatio = 0
+		cb, now := newTestCircuitBreaker(next, settings)
+
+		_, _ = cb.Do(newGetRequest(context.Background()))
+		_, _ = cb.Do(newGetRequest(context.Background()))
+		*now = now.Add(time.Minute)
+		_, _ = cb.Do(newGetRequest(context.Background()))
+		_, _ = cb.Do(newGetRequest(context.Background()))
+
+		assert.Equal(t, StateClosed, cb.State())
+		assert.Equal(t, 2, cb.Status().ConsecutiveFailures)
+	})
+
+	t.Run("should close after a successful probe in half-open state", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, errors.New("timeout")).Times(3)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusOK, ""), nil).Once()
+		cb, now := newTestCircuitBreaker(next, testCircuitSettings)
+
+		for range 3 {
+			_, _ = cb.Do(newGetRequest(context.Background()))
+		}
+		*now = now.Add(30 * time.Second)
+		assert.Equal(t, StateHalfOpen, cb.State())
+
+		_, err := cb.Do(newGetRequest(context.Background()))
+
+		assert.NoError(t, err)
+		assert.Equal(t, StateClosed, cb.State())
+	})
+
+	t.Run("should reopen after a failed probe in half-open state", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, errors.New("timeout")).Times(4)
+		cb, now := newTestCircuitBreaker(next, testCircuitSettings)
+
+		for range 3 {
+			_, _ = cb.Do(newGetRequest(context.Background()))
+		}
+		*now = now.Add(30 * time.Second)
+		_, _ = cb.Do(newGetRequest(context.Background()))
+
+		assert.Equal(t, StateOpen, cb.State())
+	})
+
+	t.Run("should limit concurrent probes in half-open state", func(t *testing.T) {
+		release := make(chan struct{})
+		probing := make(chan struct{})
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, errors.New("timeout")).Times(3)
+		next.On("Do", mock.Anything).Run(func(mock.Arguments) {
+			close(probing)
+			<-release
+		}).Return(NewTestResponse(http.StatusOK, ""), nil).Once()
+		cb, now := newTestCircuitBreaker(next, testCircuitSettings)
+
+		for range 3 {
+			_, _ = cb.Do(newGetRequest(context.Background()))
+		}
+		*now = now.Add(30 * time.Second)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = cb.Do(newGetRequest(context.Background()))
+		}()
+		<-probing
+		_, err := cb.Do(newGetRequest(context.Background()))
+		close(release)
+		<-done
+
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+		assert.Equal(t, StateClosed, cb.State())
+	})
+
+	t.Run("should ignore requests cancelled by the caller", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, context.Canceled).Times(5)
+		cb, _ := newTestCircuitBreaker(next, testCircuitSettings)
+
+		for range 5 {
+			_, _ = cb.Do(newGetRequest(ctx))
+		}
+
+		assert.Equal(t, StateClosed, cb.State())
+		assert.Equal(t, 0, cb.Status().Requests)
+	})
+}
+
+func TestCircuitState_String(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "half-open", StateHalfOpen.String())
+	assert.Equal(t, "unknown", CircuitState(99).String())
+}
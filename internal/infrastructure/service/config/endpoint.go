@@ -4,20 +4,29 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
+// pathParamPlaceholder marks where SetPath puts each path parameter, as in
+// the configured paths such as "/ws/%s/json".
+const pathParamPlaceholder = "%s"
+
+// Endpoint is an immutable URL builder: every method returns a modified copy
+// and leaves the receiver untouched, so a template can be shared by
+// concurrent requests and an error in one build never leaks into another.
 type Endpoint struct {
 	baseURL string
 	path    string
+	rawPath string
 	query   url.Values
 	err     error
 }
 
-func NewEndpoint() *Endpoint {
-	return &Endpoint{query: url.Values{}}
+func NewEndpoint() Endpoint {
+	return Endpoint{query: url.Values{}}
 }
 
-func (e *Endpoint) SetBaseURL(baseURL string) *Endpoint {
+func (e Endpoint) SetBaseURL(baseURL string) Endpoint {
 	if e.err != nil {
 		return e
 	}
@@ -30,12 +39,16 @@ func (e *Endpoint) SetBaseURL(baseURL string) *Endpoint {
 
 	e.baseURL = parsed.Scheme + "://" + parsed.Host
 	e.path = parsed.Path
+	e.rawPath = parsed.RawPath
 	e.query = parsed.Query()
 
 	return e
 }
 
-func (e *Endpoint) SetPath(path string) *Endpoint {
+// SetPath replaces the path with template, whose "%s" placeholders are
+// filled in order with params. Params are escaped, so a value holding "/",
+// "?" or "#" stays a single path segment.
+func (e Endpoint) SetPath(template string, params ...string) Endpoint {
 	if e.err != nil {
 		return e
 	}
@@ -45,11 +58,27 @@ func (e *Endpoint) SetPath(path string) *Endpoint {
 		return e
 	}
 
-	e.path = path
+	parts := strings.Split(template, pathParamPlaceholder)
+	if len(parts)-1 != len(params) {
+		e.err = fmt.Errorf("path %q expects %d parameters, got %d", template, len(parts)-1, len(params))
+		return e
+	}
+
+	var path, rawPath strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			path.WriteString(params[i-1])
+			rawPath.WriteString(url.PathEscape(params[i-1]))
+		}
+		path.WriteString(part)
+		rawPath.WriteString(part)
+	}
+	e.path = path.String()
+	e.rawPath = rawPath.String()
 	return e
 }
 
-func (e *Endpoint) AddQueryParam(key, value string) *Endpoint {
+func (e Endpoint) AddQueryParam(key, value string) Endpoint {
 	if e.err != nil {
 		return e
 	}
@@ -64,11 +93,16 @@ func (e *Endpoint) AddQueryParam(key, value string) *Endpoint {
 		return e
 	}
 
-	e.query.Set(key, value)
+	query := make(url.Values, len(e.query)+1)
+	for k, v := range e.query {
+		query[k] = v
+	}
+	query.Set(key, value)
+	e.query = query
 	return e
 }
 
-func (e *Endpoint) GetUrl() (string, error) {
+func (e Endpoint) GetUrl() (string, error) {
 	if e.err != nil {
 		return "", e.err
 	}
@@ -80,6 +114,7 @@ func (e *Endpoint) GetUrl() (string, error) {
 		Scheme:   "http",
 		Host:     "",
 		Path:     e.path,
+		RawPath:  e.rawPath,
 		RawQuery: e.query.Encode(),
 	}
 
@@ -93,6 +128,6 @@ func (e *Endpoint) GetUrl() (string, error) {
 	return u.String(), nil
 }
 
-func (e *Endpoint) Build() (string, error) {
+func (e Endpoint) Build() (string, error) {
 	return e.GetUrl()
 }
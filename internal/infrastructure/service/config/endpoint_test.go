@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -90,10 +92,10 @@ func TestEndpoint_Build(t *testing.T) {
 		assert.Equal(t, "URL not set", err.Error())
 	})
 
-	t.Run("should accumulate errors when multiple methods fail", func(t *testing.T) {
-		e := NewEndpoint()
-		e.SetPath("/api/1")
-		e.AddQueryParam("key", "value")
+	t.Run("should keep the first error when multiple methods fail", func(t *testing.T) {
+		e := NewEndpoint().
+			SetPath("/api/1").
+			AddQueryParam("key", "value")
 		_, err := e.Build()
 		assert.Error(t, err)
 		// first error should be reported
@@ -170,3 +172,67 @@ func TestAddQueryParam_EmptyKeyOrValue(t *testing.T) {
 		assert.Contains(t, url, "key=value")
 	})
 }
+
+func TestEndpoint_SetPathParams(t *testing.T) {
+	base := NewEndpoint().SetBaseURL("https://viacep.com.br")
+
+	t.Run("should fill the placeholders in order", func(t *testing.T) {
+		url, err := base.SetPath("/api/%s/items/%s", "42", "7").Build()
+		assert.NoError(t, err)
+		assert.Equal(t, "https://viacep.com.br/api/42/items/7", url)
+	})
+
+	t.Run("should escape params so they stay a single segment", func(t *testing.T) {
+		url, err := base.SetPath("/ws/%s/json", "01001/../x?y=1#z").Build()
+		assert.NoError(t, err)
+		assert.Equal(t, "https://viacep.com.br/ws/01001%2F..%2Fx%3Fy=1%23z/json", url)
+	})
+
+	t.Run("should return error when params do not match the placeholders", func(t *testing.T) {
+		_, err := base.SetPath("/ws/%s/json").Build()
+		assert.EqualError(t, err, `path "/ws/%s/json" expects 1 parameters, got 0`)
+
+		_, err = base.SetPath("/ws/json", "01001000").Build()
+		assert.EqualError(t, err, `path "/ws/json" expects 0 parameters, got 1`)
+	})
+}
+
+func TestEndpoint_Immutable(t *testing.T) {
+	t.Run("should leave the receiver untouched", func(t *testing.T) {
+		base := NewEndpoint().SetBaseURL("http://example.com").AddQueryParam("a", "1")
+
+		_ = base.SetPath("/other").AddQueryParam("a", "2").AddQueryParam("b", "3")
+		_ = base.AddQueryParam("", "")
+
+		url, err := base.Build()
+		assert.NoError(t, err)
+		assert.Equal(t, "http://example.com?a=1", url)
+	})
+
+	t.Run("should build from a shared template concurrently", func(t *testing.T) {
+		base := NewEndpoint().SetBaseURL("http://example.com").AddQueryParam("key", "k")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				id := strconv.Itoa(i)
+				ep := base.SetPath("/items/%s", id).AddQueryParam("q", id)
+				if i%5 == 0 {
+					ep = ep.AddQueryParam("broken", "")
+				}
+
+				url, err := ep.Build()
+
+				if i%5 == 0 {
+					assert.Error(t, err)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("http://example.com/items/%d?key=k&q=%d", i, i), url)
+			}(i)
+		}
+		wg.Wait()
+	})
+}
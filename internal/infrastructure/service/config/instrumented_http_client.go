@@ -0,0 +1,63 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/metrics"
+)
+
+type instrumentedHTTPDoer struct {
+	next     HTTPDoer
+	upstream string
+	metrics  *metrics.UpstreamMetrics
+	now      func() time.Time
+}
+
+// NewInstrumentedHTTPDoer records the count, latency and error class of every
+// call made through next under the upstream label. Wrapped around the
+// circuit breaker, it sees one call per request, retries included, and counts
+// calls the open breaker rejected.
+func NewInstrumentedHTTPDoer(next HTTPDoer, upstream string, upstreamMetrics *metrics.UpstreamMetrics) HTTPDoer {
+	return &instrumentedHTTPDoer{
+		next:     next,
+		upstream: upstream,
+		metrics:  upstreamMetrics,
+		now:      time.Now,
+	}
+}
+
+func (d *instrumentedHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	start := d.now()
+	resp, err := d.next.Do(req)
+
+	status := 0
+	if err == nil && resp != nil {
+		status = resp.StatusCode
+	}
+	d.metrics.Observe(d.upstream, status, errorClass(status, err), d.now().Sub(start))
+	return resp, err
+}
+
+func errorClass(status int, err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrCircuitOpen):
+		return metrics.ErrorClassCircuitOpen
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return metrics.ErrorClassTimeout
+	case errors.Is(err, context.Canceled):
+		return metrics.ErrorClassCanceled
+	case err != nil:
+		return metrics.ErrorClassNetwork
+	case status >= http.StatusInternalServerError:
+		return metrics.ErrorClassServerError
+	case status >= http.StatusBadRequest:
+		return metrics.ErrorClassClientError
+	default:
+		return metrics.ErrorClassNone
+	}
+}
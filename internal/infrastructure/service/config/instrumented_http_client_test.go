@@ -0,0 +1,80 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/metrics"
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInstrumentedDoer(next HTTPDoer) (HTTPDoer, *metrics.Registry) {
+	registry := metrics.NewRegistry()
+	doer := NewInstrumentedHTTPDoer(next, "via_cep", metrics.NewUpstreamMetrics(registry)).(*instrumentedHTTPDoer)
+	calls := 0
+	doer.now = func() time.Time {
+		calls++
+		return time.Unix(0, 0).Add(time.Duration(calls) * 200 * time.Millisecond)
+	}
+	return doer, registry
+}
+
+func exposition(t *testing.T, registry *metrics.Registry) string {
+	var out strings.Builder
+	require.NoError(t, registry.WriteText(&out))
+	return out.String()
+}
+
+func TestInstrumentedHTTPDoer_Do(t *testing.T) {
+	t.Run("should count successful calls and their latency", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusOK, "ok"), nil).Once()
+		doer, registry := newTestInstrumentedDoer(next)
+
+		resp, err := doer.Do(newGetRequest(context.Background()))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		out := exposition(t, registry)
+		assert.Contains(t, out, `upstream_requests_total{upstream="via_cep",status="200"} 1`)
+		assert.Contains(t, out, `upstream_request_duration_seconds_bucket{upstream="via_cep",le="0.25"} 1`)
+		assert.Contains(t, out, `upstream_request_duration_seconds_bucket{upstream="via_cep",le="0.1"} 0`)
+		assert.Contains(t, out, `upstream_request_duration_seconds_sum{upstream="via_cep"} 0.2`)
+		assert.NotContains(t, out, "upstream_errors_total{")
+	})
+
+	t.Run("should classify failed calls", func(t *testing.T) {
+		cases := []struct {
+			resp   *http.Response
+			err    error
+			status string
+			class  string
+		}{
+			{NewTestResponse(http.StatusServiceUnavailable, ""), nil, "503", metrics.ErrorClassServerError},
+			{NewTestResponse(http.StatusTooManyRequests, ""), nil, "429", metrics.ErrorClassClientError},
+			{nil, ErrCircuitOpen, "0", metrics.ErrorClassCircuitOpen},
+			{nil, context.DeadlineExceeded, "0", metrics.ErrorClassTimeout},
+			{nil, context.Canceled, "0", metrics.ErrorClassCanceled},
+			{nil, errors.New("connection refused"), "0", metrics.ErrorClassNetwork},
+		}
+		for _, c := range cases {
+			next := configMock.NewMockHTTPDoer(t)
+			next.On("Do", mock.Anything).Return(c.resp, c.err).Once()
+			doer, registry := newTestInstrumentedDoer(next)
+
+			_, err := doer.Do(newGetRequest(context.Background()))
+
+			assert.Equal(t, c.err, err)
+			out := exposition(t, registry)
+			assert.Contains(t, out, `upstream_requests_total{upstream="via_cep",status="`+c.status+`"} 1`)
+			assert.Contains(t, out, `upstream_errors_total{upstream="via_cep",class="`+c.class+`"} 1`)
+		}
+	})
+}
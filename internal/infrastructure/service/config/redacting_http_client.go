@@ -0,0 +1,23 @@
+package config
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/redact"
+)
+
+type redactingHTTPDoer struct {
+	next HTTPDoer
+}
+
+// NewRedactingHTTPDoer hides API keys sent in the query string from the
+// errors of next, which quote the full URL. Wrapped right around the HTTP
+// client, no retry, span, log or response ever sees the key.
+func NewRedactingHTTPDoer(next HTTPDoer) HTTPDoer {
+	return &redactingHTTPDoer{next: next}
+}
+
+func (d *redactingHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.next.Do(req)
+	return resp, redact.Error(err)
+}
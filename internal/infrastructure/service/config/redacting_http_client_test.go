@@ -0,0 +1,52 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactingHTTPDoer_Do(t *testing.T) {
+	t.Run("should hide the API key from transport errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/v1/current.json?key=s3cr3t&q=x", nil)
+		require.NoError(t, err)
+
+		_, err = NewRedactingHTTPDoer(http.DefaultClient).Do(req)
+
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "s3cr3t")
+		assert.Contains(t, err.Error(), "key=REDACTED")
+	})
+
+	t.Run("should pass responses through untouched", func(t *testing.T) {
+		req := newGetRequest(context.Background())
+		resp := NewTestResponse(http.StatusOK, "ok")
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", req).Return(resp, nil).Once()
+
+		got, err := NewRedactingHTTPDoer(next).Do(req)
+
+		assert.NoError(t, err)
+		assert.Same(t, resp, got)
+	})
+
+	t.Run("should keep errors without secrets as they are", func(t *testing.T) {
+		req := newGetRequest(context.Background())
+		want := errors.New("connection refused")
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, want).Once()
+
+		_, err := NewRedactingHTTPDoer(next).Do(req)
+
+		assert.Same(t, want, err)
+	})
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/requestid"
+)
+
+type requestIDHTTPDoer struct {
+	next HTTPDoer
+}
+
+// NewRequestIDHTTPDoer forwards the request ID of the inbound request in the
+// X-Request-ID header, so upstream logs can be matched with ours.
+func NewRequestIDHTTPDoer(next HTTPDoer) HTTPDoer {
+	return &requestIDHTTPDoer{next: next}
+}
+
+func (d *requestIDHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	id := requestid.FromContext(req.Context())
+	if id == "" || req.Header.Get(requestid.Header) != "" {
+		return d.next.Do(req)
+	}
+
+	outbound := req.Clone(req.Context())
+	outbound.Header.Set(requestid.Header, id)
+	return d.next.Do(outbound)
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/requestid"
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRequestIDHTTPDoer_Do(t *testing.T) {
+	t.Run("should forward the request ID of the context", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.Header.Get("X-Request-ID") == "req-1"
+		})).Return(NewTestResponse(http.StatusOK, "ok"), nil).Once()
+		req := newGetRequest(requestid.NewContext(context.Background(), "req-1"))
+
+		_, err := NewRequestIDHTTPDoer(next).Do(req)
+
+		assert.NoError(t, err)
+		assert.Empty(t, req.Header.Get("X-Request-ID"))
+	})
+
+	t.Run("should send the request untouched outside a request", func(t *testing.T) {
+		req := newGetRequest(context.Background())
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", req).Return(NewTestResponse(http.StatusOK, "ok"), nil).Once()
+
+		_, err := NewRequestIDHTTPDoer(next).Do(req)
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+type retryHTTPDoer struct {
+	next   HTTPDoer
+	policy RetryPolicy
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(d time.Duration) time.Duration
+}
+
+// NewRetryHTTPDoer retries idempotent requests that fail with a network error,
+// 429 or 5xx. Waits grow exponentially with full jitter, a Retry-After header
+// takes precedence, and no retry is attempted when the wait would outlive the
+// request context deadline or exceed policy.MaxBackoff.
+func NewRetryHTTPDoer(next HTTPDoer, policy RetryPolicy) HTTPDoer {
+	return &retryHTTPDoer{
+		next:   next,
+		policy: policy,
+		now:    time.Now,
+		sleep:  sleepContext,
+		jitter: fullJitter,
+	}
+}
+
+func (d *retryHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	if d.policy.MaxAttempts <= 1 || !isIdempotent(req) {
+		return d.next.Do(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := d.next.Do(req)
+		if attempt >= d.policy.MaxAttempts || ctx.Err() != nil || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		delay, ok := d.delay(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		if deadline, hasDeadline := ctx.Deadline(); hasDeadline && d.now().Add(delay).After(deadline) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err := d.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *retryHTTPDoer) delay(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), d.now()); ok {
+			return retryAfter, retryAfter <= d.policy.MaxBackoff
+		}
+	}
+
+	backoff := d.policy.InitialBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > d.policy.MaxBackoff {
+		backoff = d.policy.MaxBackoff
+	}
+	return d.jitter(backoff), true
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return req.Body == nil || req.Body == http.NoBody
+	default:
+		return false
+	}
+}
+
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(d) + 1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
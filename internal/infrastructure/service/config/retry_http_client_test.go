@@ -0,0 +1,195 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     time.Second,
+}
+
+func newTestRetryDoer(next HTTPDoer, policy RetryPolicy) (*retryHTTPDoer, *[]time.Duration) {
+	var sleeps []time.Duration
+	doer := NewRetryHTTPDoer(next, policy).(*retryHTTPDoer)
+	doer.jitter = func(d time.Duration) time.Duration { return d }
+	doer.sleep = func(_ context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return doer, &sleeps
+}
+
+func newGetRequest(ctx context.Context) *http.Request {
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com", nil)
+	return req
+}
+
+func TestRetryHTTPDoer_Do(t *testing.T) {
+	t.Run("should not retry when response is successful", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusOK, "ok"), nil).Once()
+		doer, sleeps := newTestRetryDoer(next, testRetryPolicy)
+
+		resp, err := doer.Do(newGetRequest(context.Background()))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, *sleeps)
+	})
+
+	t.Run("should retry on network errors and 5xx with exponential backoff", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, errors.New("connection reset")).Once()
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusBadGateway, ""), nil).Once()
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusOK, "ok"), nil).Once()
+		doer, sleeps := newTestRetryDoer(next, testRetryPolicy)
+
+		resp, err := doer.Do(newGetRequest(context.Background()))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, *sleeps)
+	})
+
+	t.Run("should return last response when attempts are exhausted", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusServiceUnavailable, ""), nil).Times(3)
+		doer, sleeps := newTestRetryDoer(next, testRetryPolicy)
+
+		resp, err := doer.Do(newGetRequest(context.Background()))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Len(t, *sleeps, 2)
+	})
+
+	t.Run("should not retry on 4xx other than 429", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusBadRequest, ""), nil).Once()
+		doer, _ := newTestRetryDoer(next, testRetryPolicy)
+
+		resp, _ := doer.Do(newGetRequest(context.Background()))
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should honor Retry-After header in seconds", func(t *testing.T) {
+		tooMany := NewTestResponse(http.StatusTooManyRequests, "")
+		tooMany.Header = http.Header{"Retry-After": []string{"1"}}
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(tooMany, nil).Once()
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusOK, "ok"), nil).Once()
+		doer, sleeps := newTestRetryDoer(next, testRetryPolicy)
+
+		resp, _ := doer.Do(newGetRequest(context.Background()))
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []time.Duration{time.Second}, *sleeps)
+	})
+
+	t.Run("should give up when Retry-After exceeds max backoff", func(t *testing.T) {
+		tooMany := NewTestResponse(http.StatusTooManyRequests, "")
+		tooMany.Header = http.Header{"Retry-After": []string{"120"}}
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(tooMany, nil).Once()
+		doer, sleeps := newTestRetryDoer(next, testRetryPolicy)
+
+		resp, _ := doer.Do(newGetRequest(context.Background()))
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Empty(t, *sleeps)
+	})
+
+	t.Run("should not retry when backoff would exceed context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusInternalServerError, ""), nil).Once()
+		doer, sleeps := newTestRetryDoer(next, testRetryPolicy)
+
+		resp, _ := doer.Do(newGetRequest(ctx))
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Empty(t, *sleeps)
+	})
+
+	t.Run("should stop when context is cancelled while waiting", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, errors.New("connection reset")).Once()
+		doer := NewRetryHTTPDoer(next, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour})
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		resp, err := doer.Do(newGetRequest(ctx))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("should not retry non idempotent requests", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusBadGateway, ""), nil).Once()
+		doer, _ := newTestRetryDoer(next, testRetryPolicy)
+		req, _ := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("{}"))
+
+		resp, _ := doer.Do(req)
+
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	})
+
+	t.Run("should cap exponential backoff at max backoff", func(t *testing.T) {
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, errors.New("timeout")).Times(5)
+		doer, sleeps := newTestRetryDoer(next, RetryPolicy{MaxAttempts: 5, InitialBackoff: 400 * time.Millisecond, MaxBackoff: time.Second})
+
+		_, err := doer.Do(newGetRequest(context.Background()))
+
+		assert.Error(t, err)
+		assert.Equal(t, []time.Duration{400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}, *sleeps)
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should parse delay in seconds", func(t *testing.T) {
+		d, ok := parseRetryAfter("5", now)
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Second, d)
+	})
+
+	t.Run("should parse http date", func(t *testing.T) {
+		d, ok := parseRetryAfter(now.Add(3*time.Second).Format(http.TimeFormat), now)
+		assert.True(t, ok)
+		assert.Equal(t, 3*time.Second, d)
+	})
+
+	t.Run("should ignore empty or invalid values", func(t *testing.T) {
+		_, ok := parseRetryAfter("", now)
+		assert.False(t, ok)
+		_, ok = parseRetryAfter("soon", now)
+		assert.False(t, ok)
+	})
+}
+
+func TestFullJitter(t *testing.T) {
+	t.Run("should stay between zero and the given duration", func(t *testing.T) {
+		for range 100 {
+			d := fullJitter(time.Second)
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, time.Second)
+		}
+		assert.Equal(t, time.Duration(0), fullJitter(0))
+	})
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracingHTTPDoer struct {
+	next     HTTPDoer
+	upstream string
+	tracer   trace.Tracer
+}
+
+// NewTracingHTTPDoer wraps each call to next in a client span and sends the
+// trace context along in the traceparent header. Wrapped right around the
+// HTTP client, every retry attempt gets its own span.
+func NewTracingHTTPDoer(next HTTPDoer, upstream string, provider trace.TracerProvider) HTTPDoer {
+	return &tracingHTTPDoer{
+		next:     next,
+		upstream: upstream,
+		tracer:   provider.Tracer(tracing.InstrumentationName),
+	}
+}
+
+func (d *tracingHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx, span := d.tracer.Start(req.Context(), req.Method+" "+d.upstream,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("upstream", d.upstream),
+		))
+	defer span.End()
+
+	// Clone so the header is not left behind on a request a retry reuses.
+	outbound := req.Clone(ctx)
+	tracing.Propagator.Inject(ctx, propagation.HeaderCarrier(outbound.Header))
+
+	resp, err := d.next.Do(outbound)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, fmt.Sprintf("status %d", resp.StatusCode))
+	}
+	return resp, err
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingHTTPDoer_Do(t *testing.T) {
+	provider, exporter := tracing.NewInMemoryTracerProvider()
+
+	t.Run("should send traceparent of a client span child of the caller", func(t *testing.T) {
+		exporter.Reset()
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "GetTemperatureByZipCode")
+		var outbound *http.Request
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Run(func(args mock.Arguments) {
+			outbound = args.Get(0).(*http.Request)
+		}).Return(NewTestResponse(http.StatusOK, "ok"), nil).Once()
+		req := newGetRequest(ctx)
+
+		_, err := NewTracingHTTPDoer(next, "via_cep", provider).Do(req)
+		parent.End()
+
+		require.NoError(t, err)
+		assert.Empty(t, req.Header.Get("traceparent"))
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		client := spans[0]
+		assert.Equal(t, "GET via_cep", client.Name)
+		assert.Equal(t, trace.SpanKindClient, client.SpanKind)
+		assert.Equal(t, parent.SpanContext().SpanID(), client.Parent.SpanID())
+		assert.Equal(t, "00-"+client.SpanContext.TraceID().String()+"-"+client.SpanContext.SpanID().String()+"-01",
+			outbound.Header.Get("traceparent"))
+		assert.Contains(t, client.Attributes, attribute.Int("http.response.status_code", http.StatusOK))
+	})
+
+	t.Run("should mark the span failed on errors and 5xx", func(t *testing.T) {
+		exporter.Reset()
+		next := configMock.NewMockHTTPDoer(t)
+		next.On("Do", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+		next.On("Do", mock.Anything).Return(NewTestResponse(http.StatusServiceUnavailable, ""), nil).Once()
+		doer := NewTracingHTTPDoer(next, "weather", provider)
+
+		_, _ = doer.Do(newGetRequest(context.Background()))
+		_, _ = doer.Do(newGetRequest(context.Background()))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, "connection refused", spans[0].Status.Description)
+		assert.Len(t, spans[0].Events, 1)
+		assert.Equal(t, codes.Error, spans[1].Status.Code)
+		assert.Equal(t, "status 503", spans[1].Status.Description)
+	})
+}
@@ -0,0 +1,17 @@
+package dto
+
+type BrasilApiCepDto struct {
+	ZipCode      string `json:"cep,omitempty"`
+	State        string `json:"state,omitempty"`
+	City         string `json:"city,omitempty"`
+	Neighborhood string `json:"neighborhood,omitempty"`
+	Street       string `json:"street,omitempty"`
+	Service      string `json:"service,omitempty"`
+	Location     struct {
+		Type        string `json:"type,omitempty"`
+		Coordinates struct {
+			Longitude string `json:"longitude,omitempty"`
+			Latitude  string `json:"latitude,omitempty"`
+		} `json:"coordinates"`
+	} `json:"location"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+type IbgeStateDto struct {
+	Acronym string `json:"sigla"`
+}
+
+// IbgeMunicipalityDto is the answer of the IBGE localidades API. The UF is
+// nested under the micro region and, for municipalities created after the
+// micro regions were retired, only under the immediate region.
+type IbgeMunicipalityDto struct {
+	ID          int    `json:"id"`
+	Name        string `json:"nome"`
+	MicroRegion *struct {
+		MesoRegion struct {
+			State IbgeStateDto `json:"UF"`
+		} `json:"mesorregiao"`
+	} `json:"microrregiao"`
+	ImmediateRegion *struct {
+		IntermediateRegion struct {
+			State IbgeStateDto `json:"UF"`
+		} `json:"regiao-intermediaria"`
+	} `json:"regiao-imediata"`
+}
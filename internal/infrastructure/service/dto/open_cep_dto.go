@@ -0,0 +1,11 @@
+package dto
+
+type OpenCepDto struct {
+	ZipCode      string `json:"cep,omitempty"`
+	Street       string `json:"logradouro,omitempty"`
+	Complement   string `json:"complemento,omitempty"`
+	Neighborhood string `json:"bairro,omitempty"`
+	City         string `json:"localidade,omitempty"`
+	State        string `json:"uf,omitempty"`
+	IBGE         string `json:"ibge,omitempty"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+type OpenMeteoGeocodingResultDto struct {
+	Name        string  `json:"name"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	CountryCode string  `json:"country_code"`
+	Country     string  `json:"country"`
+	Admin1      string  `json:"admin1"`
+	Timezone    string  `json:"timezone"`
+}
+
+type OpenMeteoGeocodingDto struct {
+	Results []OpenMeteoGeocodingResultDto `json:"results"`
+}
+
+type OpenMeteoForecastDto struct {
+	Current struct {
+		Time          string  `json:"time"`
+		Temperature2m float64 `json:"temperature_2m"`
+	} `json:"current"`
+}
+
+type OpenMeteoErrorDto struct {
+	Error  bool   `json:"error"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+type OpenWeatherMapDto struct {
+	Name  string `json:"name"`
+	Dt    int64  `json:"dt"`
+	Coord struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	} `json:"coord"`
+	// Timezone is the shift in seconds from UTC.
+	Timezone int `json:"timezone"`
+	Main     struct {
+		Temp float64 `json:"temp"`
+	} `json:"main"`
+	Sys struct {
+		Country string `json:"country"`
+	} `json:"sys"`
+}
+
+type OpenWeatherMapErrorDto struct {
+	Cod     interface{} `json:"cod"`
+	Message string      `json:"message"`
+}
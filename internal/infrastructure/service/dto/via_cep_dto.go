@@ -6,5 +6,6 @@ type ViaCepDto struct {
 	Neighborhood string `json:"bairro,omitempty"`
 	City         string `json:"localidade,omitempty"`
 	State        string `json:"uf,omitempty"`
+	IBGE         string `json:"ibge,omitempty"`
 	Error        string `json:"erro,omitempty"`
 }
@@ -1,11 +1,31 @@
 package dto
 
+type WeatherLocationDto struct {
+	Name      string  `json:"name"`
+	Region    string  `json:"region"`
+	Country   string  `json:"country"`
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+	LocalTime string  `json:"localtime"`
+}
+
 type WeatherDto struct {
-	Location struct {
-		Name string `json:"name"`
-	} `json:"location"`
-	Current struct {
-		TempC float64 `json:"temp_c"`
+	Location WeatherLocationDto `json:"location"`
+	Current  struct {
+		TempC       float64 `json:"temp_c"`
+		FeelsLikeC  float64 `json:"feelslike_c"`
+		Humidity    int     `json:"humidity"`
+		WindKph     float64 `json:"wind_kph"`
+		WindDegree  int     `json:"wind_degree"`
+		WindDir     string  `json:"wind_dir"`
+		PressureMb  float64 `json:"pressure_mb"`
+		PrecipMm    float64 `json:"precip_mm"`
+		UV          float64 `json:"uv"`
+		LastUpdated string  `json:"last_updated"`
+		Condition   struct {
+			Text string `json:"text"`
+			Icon string `json:"icon"`
+		} `json:"condition"`
 	} `json:"current"`
 }
 
@@ -15,3 +35,55 @@ type WeatherErrorDto struct {
 		Message string `json:"message"`
 	} `json:"error"`
 }
+
+type WeatherForecastDto struct {
+	Location WeatherLocationDto `json:"location"`
+	Forecast struct {
+		ForecastDay []struct {
+			Date string `json:"date"`
+			Day  struct {
+				MaxTempC float64 `json:"maxtemp_c"`
+				MinTempC float64 `json:"mintemp_c"`
+				AvgTempC float64 `json:"avgtemp_c"`
+			} `json:"day"`
+			Hour []struct {
+				Time  string  `json:"time"`
+				TempC float64 `json:"temp_c"`
+			} `json:"hour"`
+		} `json:"forecastday"`
+	} `json:"forecast"`
+}
+
+type WeatherAirQualityDto struct {
+	CO           float64 `json:"co"`
+	NO2          float64 `json:"no2"`
+	O3           float64 `json:"o3"`
+	SO2          float64 `json:"so2"`
+	PM2_5        float64 `json:"pm2_5"`
+	PM10         float64 `json:"pm10"`
+	USEPAIndex   int     `json:"us-epa-index"`
+	GBDefraIndex int     `json:"gb-defra-index"`
+}
+
+type WeatherAlertDto struct {
+	Headline    string `json:"headline"`
+	Severity    string `json:"severity"`
+	Urgency     string `json:"urgency"`
+	Areas       string `json:"areas"`
+	Category    string `json:"category"`
+	Event       string `json:"event"`
+	Effective   string `json:"effective"`
+	Expires     string `json:"expires"`
+	Desc        string `json:"desc"`
+	Instruction string `json:"instruction"`
+}
+
+type WeatherAirQualityReportDto struct {
+	Location WeatherLocationDto `json:"location"`
+	Current  struct {
+		AirQuality WeatherAirQualityDto `json:"air_quality"`
+	} `json:"current"`
+	Alerts struct {
+		Alert []WeatherAlertDto `json:"alert"`
+	} `json:"alerts"`
+}
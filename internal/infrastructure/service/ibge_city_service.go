@@ -0,0 +1,86 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/dto"
+)
+
+type ibgeCityService struct {
+	settings EndpointSettings
+	client   config.HTTPDoer
+}
+
+// NewIbgeCityService resolves municipality codes through the IBGE localidades
+// API, which needs no API key.
+func NewIbgeCityService(client config.HTTPDoer, settings EndpointSettings) gateway.CityService {
+	if client == nil {
+		client = config.NewHTTPClient(3 * time.Second)
+	}
+	return &ibgeCityService{
+		settings: settings,
+		client:   client,
+	}
+}
+
+func (s *ibgeCityService) GetCityByIBGECode(ctx context.Context, ibgeCode model.IBGECode) (*model.City, *model.CustomError) {
+	ep := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.Path, ibgeCode.ToString())
+
+	url, err := ep.Build()
+	if err != nil {
+		return nil, endpointError(err)
+	}
+
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "ibge")
+	if customErr != nil {
+		return nil, customErr
+	}
+	if statusCode != http.StatusOK {
+		return nil, unexpectedStatusError(statusCode, "ibge", body)
+	}
+
+	// Unknown codes are answered with 200 and an empty list.
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		return nil, cityNotFoundError(ibgeCode)
+	}
+
+	var municipalityDto dto.IbgeMunicipalityDto
+	if err := json.Unmarshal(body, &municipalityDto); err != nil {
+		return nil, unmarshalError("ibge", err)
+	}
+	if municipalityDto.Name == "" {
+		return nil, cityNotFoundError(ibgeCode)
+	}
+
+	return &model.City{
+		Name:     municipalityDto.Name,
+		State:    ibgeState(municipalityDto),
+		IBGECode: strconv.Itoa(municipalityDto.ID),
+	}, nil
+}
+
+func ibgeState(municipalityDto dto.IbgeMunicipalityDto) string {
+	if municipalityDto.MicroRegion != nil && municipalityDto.MicroRegion.MesoRegion.State.Acronym != "" {
+		return municipalityDto.MicroRegion.MesoRegion.State.Acronym
+	}
+	if municipalityDto.ImmediateRegion != nil {
+		return municipalityDto.ImmediateRegion.IntermediateRegion.State.Acronym
+	}
+	return ""
+}
+
+func cityNotFoundError(ibgeCode model.IBGECode) *model.CustomError {
+	return model.NewCodedError(model.ErrorCodeLocationNotFound, http.StatusNotFound,
+		fmt.Sprintf("can not find city for ibge code %s", ibgeCode.ToString()))
+}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	config "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var ibgeSettings = servicepkg.EndpointSettings{
+	BaseURL: "https://ibge.test",
+	Path:    "/municipios/%s",
+}
+
+func TestNewIbgeCityService_DefaultClient(t *testing.T) {
+	t.Parallel()
+
+	service := servicepkg.NewIbgeCityService(nil, ibgeSettings)
+	assert.NotNil(t, service)
+}
+
+func TestIbgeCityService_GetCityByIBGECode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("should return the city with the UF of its micro region", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://ibge.test/municipios/4314902"
+		})).Return(config.NewTestResponse(200, `{"id":4314902,"nome":"Porto Alegre","microrregiao":{"mesorregiao":{"UF":{"sigla":"RS"}}}}`), nil)
+
+		svc := servicepkg.NewIbgeCityService(mockClient, ibgeSettings)
+		city, err := svc.GetCityByIBGECode(ctx, "4314902")
+
+		assert.Nil(t, err)
+		assert.Equal(t, &model.City{Name: "Porto Alegre", State: "RS", IBGECode: "4314902"}, city)
+	})
+
+	t.Run("should fall back to the UF of the immediate region", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200,
+			`{"id":5101837,"nome":"Boa Esperança do Norte","microrregiao":null,"regiao-imediata":{"regiao-intermediaria":{"UF":{"sigla":"MT"}}}}`), nil)
+
+		svc := servicepkg.NewIbgeCityService(mockClient, ibgeSettings)
+		city, err := svc.GetCityByIBGECode(ctx, "5101837")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "MT", city.State)
+	})
+
+	t.Run("should return 404 when ibge answers an empty list", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, `[]`), nil)
+
+		svc := servicepkg.NewIbgeCityService(mockClient, ibgeSettings)
+		_, err := svc.GetCityByIBGECode(ctx, "4399999")
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeLocationNotFound, err.Code)
+			assert.Equal(t, "can not find city for ibge code 4399999", err.Err)
+		}
+	})
+
+	t.Run("should hide unexpected answers from ibge", func(t *testing.T) {
+		cases := map[int]struct {
+			status int
+			code   model.ErrorCode
+		}{
+			http.StatusBadRequest:          {http.StatusBadGateway, model.ErrorCodeUpstreamError},
+			http.StatusNotFound:            {http.StatusBadGateway, model.ErrorCodeUpstreamError},
+			http.StatusTooManyRequests:     {http.StatusServiceUnavailable, model.ErrorCodeUpstreamUnavailable},
+			http.StatusInternalServerError: {http.StatusServiceUnavailable, model.ErrorCodeUpstreamUnavailable},
+		}
+		for upstream, expected := range cases {
+			mockClient := configMock.NewMockHTTPDoer(t)
+			mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(upstream, "boom"), nil)
+
+			svc := servicepkg.NewIbgeCityService(mockClient, ibgeSettings)
+			_, err := svc.GetCityByIBGECode(ctx, "4314902")
+
+			if assert.NotNil(t, err) {
+				assert.Equal(t, expected.status, err.StatusCode)
+				assert.Equal(t, expected.code, err.Code)
+				assert.Contains(t, err.Error(), fmt.Sprintf("status %d: boom", upstream))
+			}
+		}
+	})
+
+	t.Run("should return error when unmarshalling response body fails", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, "{invalid"), nil)
+
+		svc := servicepkg.NewIbgeCityService(mockClient, ibgeSettings)
+		_, err := svc.GetCityByIBGECode(ctx, "4314902")
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+			assert.Contains(t, err.Error(), "unmarshalling")
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/dto"
+)
+
+// errZipCodeNotInDataset marks a local miss. The dataset is partial, so the
+// provider chain does not let it override an upstream failure.
+var errZipCodeNotInDataset = errors.New("zip code not in local dataset")
+
+type localZipCodeService struct {
+	addresses map[string]dto.ViaCepDto
+}
+
+// NewLocalZipCodeService loads a JSON array of ViaCEP shaped addresses from
+// path and answers lookups from memory. It is meant as the last resort of the
+// zip code provider chain.
+func NewLocalZipCodeService(path string) (gateway.ViaCepService, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading zip code dataset: %w", err)
+	}
+
+	var entries []dto.ViaCepDto
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("error unmarshalling zip code dataset: %w", err)
+	}
+
+	addresses := make(map[string]dto.ViaCepDto, len(entries))
+	for _, entry := range entries {
+		if entry.City == "" {
+			continue
+		}
+		addresses[zipCodeDigits(model.ZipCode(entry.ZipCode))] = entry
+	}
+
+	return &localZipCodeService{addresses: addresses}, nil
+}
+
+func (s *localZipCodeService) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError) {
+	entry, ok := s.addresses[zipCodeDigits(zipCode)]
+	if !ok {
+		return nil, model.NewZipCodeNotFoundError().WithCause(errZipCodeNotInDataset)
+	}
+
+	return &model.Address{
+		ZipCode:      entry.ZipCode,
+		Street:       entry.Street,
+		Neighborhood: entry.Neighborhood,
+		City:         entry.City,
+		State:        entry.State,
+		IBGECode:     entry.IBGE,
+	}, nil
+}
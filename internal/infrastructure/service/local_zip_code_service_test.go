@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDataset(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "ceps.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNewLocalZipCodeService(t *testing.T) {
+	t.Run("should return error when dataset file does not exist", func(t *testing.T) {
+		_, err := servicepkg.NewLocalZipCodeService(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorContains(t, err, "error reading zip code dataset")
+	})
+
+	t.Run("should return error when dataset is not valid json", func(t *testing.T) {
+		_, err := servicepkg.NewLocalZipCodeService(writeDataset(t, "{invalid"))
+		assert.ErrorContains(t, err, "error unmarshalling zip code dataset")
+	})
+}
+
+func TestLocalZipCodeService_GetAddressByZipCode(t *testing.T) {
+	ctx := context.Background()
+	path := writeDataset(t, `[
+		{"cep":"90040-000","localidade":"Porto Alegre","uf":"RS"},
+		{"cep":"01001000","localidade":"São Paulo","uf":"SP"},
+		{"cep":"11111111","localidade":""}
+	]`)
+	svc, err := servicepkg.NewLocalZipCodeService(path)
+	require.NoError(t, err)
+
+	t.Run("should find zip codes with or without hyphen", func(t *testing.T) {
+		address, err := svc.GetAddressByZipCode(ctx, "90040000")
+		assert.Nil(t, err)
+		assert.Equal(t, "Porto Alegre", address.City)
+		assert.Equal(t, "RS", address.State)
+
+		address, err = svc.GetAddressByZipCode(ctx, "01001-000")
+		assert.Nil(t, err)
+		assert.Equal(t, "São Paulo", address.City)
+	})
+
+	t.Run("should return 404 when zip code is not in dataset", func(t *testing.T) {
+		_, err := svc.GetAddressByZipCode(ctx, "11111111")
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+			assert.Equal(t, "can not find zipcode", err.Err)
+			assert.ErrorContains(t, err, "zip code not in local dataset")
+		}
+	})
+}
@@ -0,0 +1,62 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mock
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+// MockCityService is an autogenerated mock type for the CityService type
+type MockCityService struct {
+	mock.Mock
+}
+
+// GetCityByIBGECode provides a mock function with given fields: ctx, ibgeCode
+func (_m *MockCityService) GetCityByIBGECode(ctx context.Context, ibgeCode model.IBGECode) (*model.City, *model.CustomError) {
+	ret := _m.Called(ctx, ibgeCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCityByIBGECode")
+	}
+
+	var r0 *model.City
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.IBGECode) (*model.City, *model.CustomError)); ok {
+		return rf(ctx, ibgeCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.IBGECode) *model.City); ok {
+		r0 = rf(ctx, ibgeCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.City)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.IBGECode) *model.CustomError); ok {
+		r1 = rf(ctx, ibgeCode)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// NewMockCityService creates a new instance of MockCityService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCityService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCityService {
+	mock := &MockCityService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -16,23 +16,23 @@ type MockViaCepService struct {
 }
 
 // GetAddressByZipCode provides a mock function with given fields: ctx, zipCode
-func (_m *MockViaCepService) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*string, *model.CustomError) {
+func (_m *MockViaCepService) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError) {
 	ret := _m.Called(ctx, zipCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAddressByZipCode")
 	}
 
-	var r0 *string
+	var r0 *model.Address
 	var r1 *model.CustomError
-	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) (*string, *model.CustomError)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) (*model.Address, *model.CustomError)); ok {
 		return rf(ctx, zipCode)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) *string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.ZipCode) *model.Address); ok {
 		r0 = rf(ctx, zipCode)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*string)
+			r0 = ret.Get(0).(*model.Address)
 		}
 	}
 
@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/Berchon/weather-cloud-run/internal/business/model"
+
+	time "time"
 )
 
 // MockWeatherService is an autogenerated mock type for the WeatherService type
@@ -15,28 +17,156 @@ type MockWeatherService struct {
 	mock.Mock
 }
 
+// GetAirQualityByCity provides a mock function with given fields: ctx, city
+func (_m *MockWeatherService) GetAirQualityByCity(ctx context.Context, city model.City) (*model.AirQualityReport, *model.CustomError) {
+	ret := _m.Called(ctx, city)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAirQualityByCity")
+	}
+
+	var r0 *model.AirQualityReport
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.City) (*model.AirQualityReport, *model.CustomError)); ok {
+		return rf(ctx, city)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.City) *model.AirQualityReport); ok {
+		r0 = rf(ctx, city)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AirQualityReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.City) *model.CustomError); ok {
+		r1 = rf(ctx, city)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetConditionsByCity provides a mock function with given fields: ctx, city
+func (_m *MockWeatherService) GetConditionsByCity(ctx context.Context, city model.City) (*model.WeatherConditions, *model.CustomError) {
+	ret := _m.Called(ctx, city)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConditionsByCity")
+	}
+
+	var r0 *model.WeatherConditions
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.City) (*model.WeatherConditions, *model.CustomError)); ok {
+		return rf(ctx, city)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.City) *model.WeatherConditions); ok {
+		r0 = rf(ctx, city)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WeatherConditions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.City) *model.CustomError); ok {
+		r1 = rf(ctx, city)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetForecastByCity provides a mock function with given fields: ctx, city, days
+func (_m *MockWeatherService) GetForecastByCity(ctx context.Context, city model.City, days int) (*model.WeatherForecast, *model.CustomError) {
+	ret := _m.Called(ctx, city, days)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForecastByCity")
+	}
+
+	var r0 *model.WeatherForecast
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.City, int) (*model.WeatherForecast, *model.CustomError)); ok {
+		return rf(ctx, city, days)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.City, int) *model.WeatherForecast); ok {
+		r0 = rf(ctx, city, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WeatherForecast)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.City, int) *model.CustomError); ok {
+		r1 = rf(ctx, city, days)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetHistoryByCity provides a mock function with given fields: ctx, city, date
+func (_m *MockWeatherService) GetHistoryByCity(ctx context.Context, city model.City, date time.Time) (*model.WeatherForecast, *model.CustomError) {
+	ret := _m.Called(ctx, city, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistoryByCity")
+	}
+
+	var r0 *model.WeatherForecast
+	var r1 *model.CustomError
+	if rf, ok := ret.Get(0).(func(context.Context, model.City, time.Time) (*model.WeatherForecast, *model.CustomError)); ok {
+		return rf(ctx, city, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.City, time.Time) *model.WeatherForecast); ok {
+		r0 = rf(ctx, city, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WeatherForecast)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.City, time.Time) *model.CustomError); ok {
+		r1 = rf(ctx, city, date)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.CustomError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetWeatherByCity provides a mock function with given fields: ctx, city
-func (_m *MockWeatherService) GetWeatherByCity(ctx context.Context, city string) (*float64, *model.CustomError) {
+func (_m *MockWeatherService) GetWeatherByCity(ctx context.Context, city model.City) (*model.Weather, *model.CustomError) {
 	ret := _m.Called(ctx, city)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetWeatherByCity")
 	}
 
-	var r0 *float64
+	var r0 *model.Weather
 	var r1 *model.CustomError
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*float64, *model.CustomError)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.City) (*model.Weather, *model.CustomError)); ok {
 		return rf(ctx, city)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *float64); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, model.City) *model.Weather); ok {
 		r0 = rf(ctx, city)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*float64)
+			r0 = ret.Get(0).(*model.Weather)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) *model.CustomError); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, model.City) *model.CustomError); ok {
 		r1 = rf(ctx, city)
 	} else {
 		if ret.Get(1) != nil {
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeName lowercases name, strips diacritics and collapses whitespace,
+// so "  São  Paulo" and "sao paulo" compare equal.
+func normalizeName(name string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(stripAccents, name)
+	if err != nil {
+		result = name
+	}
+	return strings.Join(strings.Fields(strings.ToLower(result)), " ")
+}
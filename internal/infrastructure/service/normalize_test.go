@@ -0,0 +1,22 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	t.Run("should remove accents and lowercase", func(t *testing.T) {
+		assert.Equal(t, "sao paulo", normalizeName("São Paulo"))
+		assert.Equal(t, "cacapava do sul", normalizeName("CAÇAPAVA DO SUL"))
+	})
+
+	t.Run("should collapse and trim whitespace", func(t *testing.T) {
+		assert.Equal(t, "porto alegre", normalizeName("  Porto   Alegre "))
+	})
+
+	t.Run("should return empty string for blank input", func(t *testing.T) {
+		assert.Equal(t, "", normalizeName("   "))
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/dto"
+)
+
+type openCepService struct {
+	settings EndpointSettings
+	client   config.HTTPDoer
+}
+
+func NewOpenCepService(client config.HTTPDoer, settings EndpointSettings) gateway.ViaCepService {
+	if client == nil {
+		client = config.NewHTTPClient(3 * time.Second)
+	}
+	return &openCepService{
+		settings: settings,
+		client:   client,
+	}
+}
+
+func (s *openCepService) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError) {
+	ep := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.Path, zipCodeDigits(zipCode))
+
+	url, err := ep.Build()
+	if err != nil {
+		return nil, endpointError(err)
+	}
+
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "open cep")
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	switch statusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		return nil, model.NewInvalidZipCodeError()
+	case http.StatusNotFound:
+		return nil, model.NewZipCodeNotFoundError()
+	default:
+		return nil, unexpectedZipCodeStatusError(statusCode, "open cep", body)
+	}
+
+	var openCepDto dto.OpenCepDto
+	if err := json.Unmarshal(body, &openCepDto); err != nil {
+		return nil, unmarshalError("open cep", err)
+	}
+
+	if openCepDto.City == "" {
+		return nil, emptyCityError("open cep")
+	}
+
+	return &model.Address{
+		ZipCode:      openCepDto.ZipCode,
+		Street:       openCepDto.Street,
+		Neighborhood: openCepDto.Neighborhood,
+		City:         openCepDto.City,
+		State:        openCepDto.State,
+		IBGECode:     openCepDto.IBGE,
+	}, nil
+}
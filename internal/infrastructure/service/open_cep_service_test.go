@@ -0,0 +1,119 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	config "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var openCepSettings = servicepkg.EndpointSettings{
+	BaseURL: "https://opencep.com",
+	Path:    "/v1/%s",
+}
+
+func TestNewOpenCepService_DefaultClient(t *testing.T) {
+	t.Parallel()
+
+	service := servicepkg.NewOpenCepService(nil, openCepSettings)
+	assert.NotNil(t, service)
+}
+
+func TestOpenCepService_GetAddressByZipCode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("should request zip code digits only", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.String() == "https://opencep.com/v1/89010025"
+		})).Return(config.NewTestResponse(200, `{"cep":"89010-025","localidade":"Blumenau","uf":"SC","ibge":"4202404"}`), nil)
+
+		svc := servicepkg.NewOpenCepService(mockClient, openCepSettings)
+		address, err := svc.GetAddressByZipCode(ctx, "89010-025")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Blumenau", address.City)
+		assert.Equal(t, "SC", address.State)
+		assert.Equal(t, "4202404", address.IBGECode)
+	})
+
+	t.Run("should return error when sending request fails", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, errors.New("http failure"))
+
+		svc := servicepkg.NewOpenCepService(mockClient, openCepSettings)
+		_, err := svc.GetAddressByZipCode(ctx, "89010025")
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamUnavailable, err.Code)
+			assert.Contains(t, err.Error(), "http failure")
+		}
+	})
+
+	t.Run("should return 503 when circuit breaker is open", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, config.ErrCircuitOpen)
+
+		svc := servicepkg.NewOpenCepService(mockClient, openCepSettings)
+		_, err := svc.GetAddressByZipCode(ctx, "89010025")
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeCircuitOpen, err.Code)
+			assert.ErrorIs(t, err, config.ErrCircuitOpen)
+		}
+	})
+
+	t.Run("should map status codes to domain errors", func(t *testing.T) {
+		cases := map[int]int{
+			http.StatusBadRequest:          http.StatusUnprocessableEntity,
+			http.StatusNotFound:            http.StatusNotFound,
+			http.StatusInternalServerError: http.StatusBadGateway,
+		}
+		for upstream, expected := range cases {
+			mockClient := configMock.NewMockHTTPDoer(t)
+			mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(upstream, `{}`), nil)
+
+			svc := servicepkg.NewOpenCepService(mockClient, openCepSettings)
+			_, err := svc.GetAddressByZipCode(ctx, "89010025")
+
+			if assert.NotNil(t, err) {
+				assert.Equal(t, expected, err.StatusCode)
+			}
+		}
+	})
+
+	t.Run("should return 502 when unmarshalling response body fails", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, "{invalid"), nil)
+
+		svc := servicepkg.NewOpenCepService(mockClient, openCepSettings)
+		_, err := svc.GetAddressByZipCode(ctx, "89010025")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshalling")
+		assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+	})
+
+	t.Run("should return 502 when city is empty in response", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, `{"cep":"89010025"}`), nil)
+
+		svc := servicepkg.NewOpenCepService(mockClient, openCepSettings)
+		_, err := svc.GetAddressByZipCode(ctx, "89010025")
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/dto"
+)
+
+// OpenMeteoSettings locates the Open-Meteo geocoding and forecast APIs.
+type OpenMeteoSettings struct {
+	GeocodingBaseURL string
+	GeocodingPath    string
+	BaseURL          string
+	Path             string
+}
+
+type openMeteoService struct {
+	settings OpenMeteoSettings
+	client   config.HTTPDoer
+}
+
+// NewOpenMeteoService queries Open-Meteo, which needs no API key but works on
+// coordinates: the city is first resolved through its geocoding API.
+func NewOpenMeteoService(client config.HTTPDoer, settings OpenMeteoSettings) gateway.WeatherService {
+	if client == nil {
+		client = config.NewHTTPClient(3 * time.Second)
+	}
+
+	return &openMeteoService{
+		settings: settings,
+		client:   client,
+	}
+}
+
+func (s *openMeteoService) GetWeatherByCity(ctx context.Context, city model.City) (*model.Weather, *model.CustomError) {
+	place, customErr := s.locate(ctx, city)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	url, err := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.Path).
+		AddQueryParam("latitude", strconv.FormatFloat(place.Latitude, 'f', -1, 64)).
+		AddQueryParam("longitude", strconv.FormatFloat(place.Longitude, 'f', -1, 64)).
+		AddQueryParam("current", "temperature_2m").
+		AddQueryParam("timezone", "auto").
+		Build()
+	if err != nil {
+		return nil, endpointError(err)
+	}
+
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "open meteo")
+	if customErr != nil {
+		return nil, customErr
+	}
+	if statusCode != http.StatusOK {
+		return nil, openMeteoError(statusCode, body)
+	}
+
+	var forecastDto dto.OpenMeteoForecastDto
+	if err := json.Unmarshal(body, &forecastDto); err != nil {
+		return nil, unmarshalError("open meteo", err)
+	}
+
+	// With timezone=auto the current time is local, formatted "2006-01-02T15:04".
+	return &model.Weather{
+		Celsius: forecastDto.Current.Temperature2m,
+		Location: model.Location{
+			Name:      place.Name,
+			Region:    place.Admin1,
+			Country:   place.Country,
+			Latitude:  place.Latitude,
+			Longitude: place.Longitude,
+			LocalTime: strings.Replace(forecastDto.Current.Time, "T", " ", 1),
+		},
+	}, nil
+}
+
+func (s *openMeteoService) GetForecastByCity(ctx context.Context, city model.City, days int) (*model.WeatherForecast, *model.CustomError) {
+	return nil, notImplementedError("open meteo", "forecast")
+}
+
+func (s *openMeteoService) GetHistoryByCity(ctx context.Context, city model.City, date time.Time) (*model.WeatherForecast, *model.CustomError) {
+	return nil, notImplementedError("open meteo", "history")
+}
+
+func (s *openMeteoService) GetConditionsByCity(ctx context.Context, city model.City) (*model.WeatherConditions, *model.CustomError) {
+	return nil, notImplementedError("open meteo", "extended conditions")
+}
+
+func (s *openMeteoService) GetAirQualityByCity(ctx context.Context, city model.City) (*model.AirQualityReport, *model.CustomError) {
+	return nil, notImplementedError("open meteo", "air quality")
+}
+
+// locate skips geocoding when the coordinates are already known. Otherwise it
+// picks, among the Brazilian homonyms of the city, the one in the right state.
+func (s *openMeteoService) locate(ctx context.Context, city model.City) (*dto.OpenMeteoGeocodingResultDto, *model.CustomError) {
+	if city.Coordinates != nil {
+		return &dto.OpenMeteoGeocodingResultDto{
+			Name:        city.Name,
+			Latitude:    city.Coordinates.Latitude,
+			Longitude:   city.Coordinates.Longitude,
+			CountryCode: "BR",
+			Country:     "Brasil",
+			Admin1:      stateName(city.State),
+		}, nil
+	}
+
+	url, err := config.NewEndpoint().
+		SetBaseURL(s.settings.GeocodingBaseURL).
+		SetPath(s.settings.GeocodingPath).
+		AddQueryParam("name", city.Name).
+		AddQueryParam("count", "10").
+		AddQueryParam("language", "pt").
+		AddQueryParam("countryCode", "BR").
+		Build()
+	if err != nil {
+		return nil, endpointError(err)
+	}
+
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "open meteo")
+	if customErr != nil {
+		return nil, customErr
+	}
+	if statusCode != http.StatusOK {
+		return nil, openMeteoError(statusCode, body)
+	}
+
+	var geocodingDto dto.OpenMeteoGeocodingDto
+	if err := json.Unmarshal(body, &geocodingDto); err != nil {
+		return nil, unmarshalError("open meteo", err)
+	}
+	for i, result := range geocodingDto.Results {
+		if isBrazil(result.CountryCode) && regionMatchesState(result.Admin1, city.State) {
+			return &geocodingDto.Results[i], nil
+		}
+	}
+
+	return nil, locationNotFoundError(city)
+}
+
+func openMeteoError(statusCode int, body []byte) *model.CustomError {
+	var apiErr dto.OpenMeteoErrorDto
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Reason != "" {
+		return upstreamRejectionError(statusCode, "open meteo", apiErr.Reason)
+	}
+	return unexpectedStatusError(statusCode, "open meteo", body)
+}
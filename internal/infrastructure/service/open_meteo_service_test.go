@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	config "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var openMeteoSettings = servicepkg.OpenMeteoSettings{
+	GeocodingBaseURL: "https://geocoding-api.open-meteo.com",
+	GeocodingPath:    "/v1/search",
+	BaseURL:          "https://api.open-meteo.com",
+	Path:             "/v1/forecast",
+}
+
+func isGeocodingRequest(req *http.Request) bool {
+	return req.URL.Host == "geocoding-api.open-meteo.com"
+}
+
+func isForecastRequest(req *http.Request) bool {
+	return req.URL.Host == "api.open-meteo.com"
+}
+
+func TestNewOpenMeteoService_DefaultClient(t *testing.T) {
+	t.Parallel()
+
+	service := servicepkg.NewOpenMeteoService(nil, openMeteoSettings)
+	assert.NotNil(t, service)
+}
+
+func TestOpenMeteoService_GetWeatherByCity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("should geocode city and return current temperature", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return isGeocodingRequest(req) && query.Get("name") == "Porto Alegre" && query.Get("countryCode") == "BR"
+		})).Return(config.NewTestResponse(200, `{"results":[{"name":"Porto Alegre","latitude":-30.03,"longitude":-51.23,"country_code":"BR","country":"Brasil","admin1":"Rio Grande do Sul"}]}`), nil).Once()
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return isForecastRequest(req) && query.Get("latitude") == "-30.03" && query.Get("longitude") == "-51.23"
+		})).Return(config.NewTestResponse(200, `{"current":{"time":"2025-07-01T14:30","temperature_2m":21.4}}`), nil).Once()
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		weather, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 21.4, weather.Celsius)
+		assert.Equal(t, model.Location{
+			Name: "Porto Alegre", Region: "Rio Grande do Sul", Country: "Brasil",
+			Latitude: -30.03, Longitude: -51.23, LocalTime: "2025-07-01 14:30",
+		}, weather.Location)
+	})
+
+	t.Run("should return 404 when geocoding finds no location", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(isGeocodingRequest)).
+			Return(config.NewTestResponse(200, `{}`), nil).Once()
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Cidade Inexistente", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		}
+	})
+
+	t.Run("should return error when sending request fails", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, errors.New("http failure"))
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamUnavailable, err.Code)
+			assert.Contains(t, err.Error(), "http failure")
+		}
+	})
+
+	t.Run("should return 503 when circuit breaker is open", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, config.ErrCircuitOpen)
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeCircuitOpen, err.Code)
+			assert.ErrorIs(t, err, config.ErrCircuitOpen)
+		}
+	})
+
+	t.Run("should answer 502 and keep the reason as cause when forecast fails", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(isGeocodingRequest)).
+			Return(config.NewTestResponse(200, `{"results":[{"latitude":-30.03,"longitude":-51.23,"country_code":"BR","admin1":"Rio Grande do Sul"}]}`), nil).Once()
+		mockClient.On("Do", mock.MatchedBy(isForecastRequest)).
+			Return(config.NewTestResponse(400, `{"error":true,"reason":"Latitude must be in range"}`), nil).Once()
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamError, err.Code)
+			assert.Equal(t, "open meteo could not answer the request", err.Err)
+			assert.Contains(t, err.Error(), "status 400: Latitude must be in range")
+		}
+	})
+
+	t.Run("should return 502 when unmarshalling geocoding response fails", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(isGeocodingRequest)).
+			Return(config.NewTestResponse(200, "{invalid"), nil).Once()
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+			assert.Contains(t, err.Error(), "unmarshalling")
+		}
+	})
+
+	t.Run("should pick the geocoding result in the expected state", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(isGeocodingRequest)).
+			Return(config.NewTestResponse(200, `{"results":[
+				{"name":"Bom Jesus","latitude":-28.67,"longitude":-50.43,"country_code":"BR","admin1":"Rio Grande do Sul"},
+				{"name":"Bom Jesus","latitude":-9.07,"longitude":-44.36,"country_code":"BR","admin1":"Piauí"}
+			]}`), nil).Once()
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return isForecastRequest(req) && req.URL.Query().Get("latitude") == "-9.07"
+		})).Return(config.NewTestResponse(200, `{"current":{"temperature_2m":31.0}}`), nil).Once()
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		weather, err := svc.GetWeatherByCity(ctx, model.City{Name: "Bom Jesus", State: "PI"})
+
+		assert.Nil(t, err)
+		if assert.NotNil(t, weather) {
+			assert.Equal(t, "Piauí", weather.Location.Region)
+		}
+	})
+
+	t.Run("should return 404 when no geocoding result is in the expected state", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(isGeocodingRequest)).
+			Return(config.NewTestResponse(200, `{"results":[
+				{"name":"Bom Jesus","latitude":-28.67,"longitude":-50.43,"country_code":"BR","admin1":"Rio Grande do Sul"}
+			]}`), nil).Once()
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Bom Jesus", State: "PI"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		}
+	})
+
+	t.Run("should skip geocoding when coordinates are known", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return isForecastRequest(req) && req.URL.Query().Get("latitude") == "-9.07"
+		})).Return(config.NewTestResponse(200, `{"current":{"temperature_2m":31.0}}`), nil).Once()
+
+		svc := servicepkg.NewOpenMeteoService(mockClient, openMeteoSettings)
+		weather, err := svc.GetWeatherByCity(ctx, model.City{
+			Name: "Bom Jesus", State: "PI", Coordinates: &model.Coordinates{Latitude: -9.07, Longitude: -44.36},
+		})
+
+		assert.Nil(t, err)
+		if assert.NotNil(t, weather) {
+			assert.Equal(t, "Piauí", weather.Location.Region)
+		}
+	})
+}
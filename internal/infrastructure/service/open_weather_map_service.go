@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/dto"
+)
+
+// OpenWeatherMapSettings locates the OpenWeatherMap current weather API and
+// carries its appid.
+type OpenWeatherMapSettings struct {
+	BaseURL string
+	Path    string
+	APIKey  string
+}
+
+type openWeatherMapService struct {
+	settings OpenWeatherMapSettings
+	client   config.HTTPDoer
+}
+
+func NewOpenWeatherMapService(client config.HTTPDoer, settings OpenWeatherMapSettings) gateway.WeatherService {
+	if client == nil {
+		client = config.NewHTTPClient(3 * time.Second)
+	}
+
+	return &openWeatherMapService{
+		settings: settings,
+		client:   client,
+	}
+}
+
+func (s *openWeatherMapService) GetWeatherByCity(ctx context.Context, city model.City) (*model.Weather, *model.CustomError) {
+	ep := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.Path).
+		AddQueryParam("appid", s.settings.APIKey).
+		AddQueryParam("units", "metric")
+	// OpenWeatherMap only understands state codes for the US, so without
+	// coordinates the best we can do is pin the country.
+	if city.Coordinates != nil {
+		ep = ep.
+			AddQueryParam("lat", strconv.FormatFloat(city.Coordinates.Latitude, 'f', -1, 64)).
+			AddQueryParam("lon", strconv.FormatFloat(city.Coordinates.Longitude, 'f', -1, 64))
+	} else {
+		ep = ep.AddQueryParam("q", city.Name+",BR")
+	}
+
+	url, err := ep.Build()
+	if err != nil {
+		return nil, endpointError(err)
+	}
+
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "open weather map")
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, openWeatherMapError(statusCode, body, city)
+	}
+
+	var weatherDto dto.OpenWeatherMapDto
+	if err := json.Unmarshal(body, &weatherDto); err != nil {
+		return nil, unmarshalError("open weather map", err)
+	}
+
+	if !isBrazil(weatherDto.Sys.Country) {
+		return nil, locationNotFoundError(city)
+	}
+
+	localTime := time.Unix(weatherDto.Dt, 0).In(time.FixedZone("", weatherDto.Timezone))
+	return &model.Weather{
+		Celsius: weatherDto.Main.Temp,
+		Location: model.Location{
+			Name:      weatherDto.Name,
+			Country:   weatherDto.Sys.Country,
+			Latitude:  weatherDto.Coord.Lat,
+			Longitude: weatherDto.Coord.Lon,
+			LocalTime: localTime.Format("2006-01-02 15:04"),
+		},
+	}, nil
+}
+
+func (s *openWeatherMapService) GetForecastByCity(ctx context.Context, city model.City, days int) (*model.WeatherForecast, *model.CustomError) {
+	return nil, notImplementedError("open weather map", "forecast")
+}
+
+func (s *openWeatherMapService) GetHistoryByCity(ctx context.Context, city model.City, date time.Time) (*model.WeatherForecast, *model.CustomError) {
+	return nil, notImplementedError("open weather map", "history")
+}
+
+func (s *openWeatherMapService) GetConditionsByCity(ctx context.Context, city model.City) (*model.WeatherConditions, *model.CustomError) {
+	return nil, notImplementedError("open weather map", "extended conditions")
+}
+
+func (s *openWeatherMapService) GetAirQualityByCity(ctx context.Context, city model.City) (*model.AirQualityReport, *model.CustomError) {
+	return nil, notImplementedError("open weather map", "air quality")
+}
+
+// openWeatherMapError translates a non 200 answer from OpenWeatherMap, which
+// reports unknown cities as 404.
+func openWeatherMapError(statusCode int, body []byte, city model.City) *model.CustomError {
+	if statusCode == http.StatusNotFound {
+		return locationNotFoundError(city).WithCause(fmt.Errorf("status %d: %s", statusCode, body))
+	}
+
+	var apiErr dto.OpenWeatherMapErrorDto
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return upstreamRejectionError(statusCode, "open weather map", apiErr.Message)
+	}
+	return unexpectedStatusError(statusCode, "open weather map", body)
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	config "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var openWeatherMapSettings = servicepkg.OpenWeatherMapSettings{
+	BaseURL: "https://api.openweathermap.org",
+	Path:    "/data/2.5/weather",
+	APIKey:  "test-key",
+}
+
+func TestNewOpenWeatherMapService_DefaultClient(t *testing.T) {
+	t.Parallel()
+
+	service := servicepkg.NewOpenWeatherMapService(nil, openWeatherMapSettings)
+	assert.NotNil(t, service)
+}
+
+func TestOpenWeatherMapService_GetWeatherByCity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("should return temperature in celsius", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return query.Get("appid") == "test-key" && query.Get("q") == "Porto Alegre,BR" && query.Get("units") == "metric"
+		})).Return(config.NewTestResponse(200, `{"name":"Porto Alegre","dt":1751391000,"timezone":-10800,"coord":{"lat":-30.03,"lon":-51.23},"main":{"temp":19.8},"sys":{"country":"BR"}}`), nil)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		weather, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 19.8, weather.Celsius)
+		assert.Equal(t, model.Location{
+			Name: "Porto Alegre", Country: "BR",
+			Latitude: -30.03, Longitude: -51.23, LocalTime: "2025-07-01 14:30",
+		}, weather.Location)
+	})
+
+	t.Run("should return error when sending request fails", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, errors.New("http failure"))
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamUnavailable, err.Code)
+			assert.Contains(t, err.Error(), "http failure")
+		}
+	})
+
+	t.Run("should return 503 when circuit breaker is open", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, config.ErrCircuitOpen)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeCircuitOpen, err.Code)
+			assert.ErrorIs(t, err, config.ErrCircuitOpen)
+		}
+	})
+
+	t.Run("should answer 502 when open weather map rejects the api key", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).
+			Return(config.NewTestResponse(401, `{"cod":401,"message":"Invalid API key."}`), nil)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamError, err.Code)
+			assert.Equal(t, "open weather map could not answer the request", err.Err)
+			assert.Contains(t, err.Error(), "status 401: Invalid API key.")
+		}
+	})
+
+	t.Run("should answer 503 when open weather map throttles", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).
+			Return(config.NewTestResponse(429, `{"cod":429,"message":"Your account is temporary blocked."}`), nil)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamUnavailable, err.Code)
+			assert.Contains(t, err.Error(), "status 429")
+		}
+	})
+
+	t.Run("should answer 404 when open weather map does not know the city", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).
+			Return(config.NewTestResponse(404, `{"cod":"404","message":"city not found"}`), nil)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeLocationNotFound, err.Code)
+		}
+	})
+
+	t.Run("should answer 503 and keep the body as cause on upstream outage", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(502, "bad gateway"), nil)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, "open weather map is temporarily unavailable", err.Err)
+			assert.Contains(t, err.Error(), "status 502: bad gateway")
+		}
+	})
+
+	t.Run("should return 502 when unmarshalling response body fails", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, "{invalid"), nil)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unmarshalling")
+		assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+	})
+
+	t.Run("should query by coordinates when they are known", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return query.Get("lat") == "-9.07" && query.Get("lon") == "-44.36" && query.Get("q") == ""
+		})).Return(config.NewTestResponse(200, `{"name":"Bom Jesus","main":{"temp":31.0},"sys":{"country":"BR"}}`), nil)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{
+			Name: "Bom Jesus", State: "PI", Coordinates: &model.Coordinates{Latitude: -9.07, Longitude: -44.36},
+		})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return 404 when location is outside Brazil", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).
+			Return(config.NewTestResponse(200, `{"name":"Sao Domingos","main":{"temp":18.0},"sys":{"country":"PT"}}`), nil)
+
+		svc := servicepkg.NewOpenWeatherMapService(mockClient, openWeatherMapSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "São Domingos", State: "GO"})
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		}
+	})
+}
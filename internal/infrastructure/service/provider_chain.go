@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+// Provider is one named upstream of a provider chain.
+type Provider[S any] struct {
+	Name    string
+	Service S
+}
+
+type ProviderStats struct {
+	Answered uint64 `json:"answered"`
+	Failed   uint64 `json:"failed"`
+}
+
+// providerOutcome tells the chain what to make of a provider's error.
+type providerOutcome int
+
+const (
+	// providerAnswered ends the chain with the provider's result.
+	providerAnswered providerOutcome = iota
+	// providerFailed counts a failure and moves on to the next provider.
+	providerFailed
+	// providerSkipped moves on without counting anything. The error is only
+	// returned when no other provider answered or failed.
+	providerSkipped
+)
+
+type providerCounters struct {
+	answered atomic.Uint64
+	failed   atomic.Uint64
+}
+
+// providerChain holds what the zip code and weather chains share: the ordered
+// providers, their counters and the rule telling which errors hand the call
+// to the next provider.
+type providerChain[S any] struct {
+	kind      string
+	providers []Provider[S]
+	counters  []*providerCounters
+	classify  func(*model.CustomError) providerOutcome
+	logger    *slog.Logger
+}
+
+// newProviderChain builds a chain that asks classify what each provider error
+// means. kind names the chain in logs and errors. A nil logger logs nowhere.
+func newProviderChain[S any](kind string, logger *slog.Logger, classify func(*model.CustomError) providerOutcome, providers []Provider[S]) *providerChain[S] {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	counters := make([]*providerCounters, len(providers))
+	for i := range providers {
+		counters[i] = &providerCounters{}
+	}
+	return &providerChain[S]{
+		kind:      kind,
+		providers: providers,
+		counters:  counters,
+		classify:  classify,
+		logger:    logger,
+	}
+}
+
+// failOver runs call against each provider in turn until one answers. When
+// none does, the last failure is returned, or else the first skipped error,
+// so a provider that merely lacks the data never hides an outage. attrs are
+// added to the chain's log lines.
+func failOver[S, T any](ctx context.Context, c *providerChain[S], call func(S) (*T, *model.CustomError), attrs ...any) (*T, *model.CustomError) {
+	var lastErr, skippedErr *model.CustomError
+
+	for i, provider := range c.providers {
+		result, err := call(provider.Service)
+		outcome := providerAnswered
+		if err != nil {
+			outcome = c.classify(err)
+		}
+
+		switch outcome {
+		case providerAnswered:
+			c.counters[i].answered.Add(1)
+			c.logger.DebugContext(ctx, c.kind+" provider answered", append([]any{"provider", provider.Name}, attrs...)...)
+			return result, err
+		case providerSkipped:
+			c.logger.DebugContext(ctx, c.kind+" provider skipped", append([]any{"provider", provider.Name, "error", err.Error()}, attrs...)...)
+			if skippedErr == nil {
+				skippedErr = err
+			}
+		default:
+			c.counters[i].failed.Add(1)
+			c.logger.WarnContext(ctx, c.kind+" provider failed", append([]any{"provider", provider.Name, "error", err.Error()}, attrs...)...)
+			lastErr = err
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	switch {
+	case lastErr != nil:
+		return nil, lastErr
+	case skippedErr != nil:
+		return nil, skippedErr
+	default:
+		return nil, model.NewCustomError(http.StatusInternalServerError, "no "+c.kind+" provider configured")
+	}
+}
+
+func (c *providerChain[S]) Stats() map[string]ProviderStats {
+	stats := make(map[string]ProviderStats, len(c.providers))
+	for i, provider := range c.providers {
+		stats[provider.Name] = ProviderStats{
+			Answered: c.counters[i].answered.Load(),
+			Failed:   c.counters[i].failed.Load(),
+		}
+	}
+	return stats
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/redact"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
+)
+
+// maxUpstreamBodyBytes bounds what is read from an upstream answer; the
+// largest one, a multi-day forecast, is a few tens of kilobytes.
+const maxUpstreamBodyBytes = 1 << 20
+
+// EndpointSettings locates an upstream answering on a single path. Path may
+// hold "%s" placeholders for the lookup key, as in "/ws/%s/json".
+type EndpointSettings struct {
+	BaseURL string
+	Path    string
+}
+
+func sendGetRequest(ctx context.Context, client config.HTTPDoer, url, upstream string) (int, []byte, *model.CustomError) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, model.NewCodedError(model.ErrorCodeInternal, http.StatusInternalServerError,
+			"error creating request").WithCause(err)
+	}
+
+	// The client may not redact: keys sent in the query string must not reach
+	// the cause, which is logged.
+	response, err := client.Do(req)
+	err = redact.Error(err)
+	if errors.Is(err, config.ErrCircuitOpen) {
+		return 0, nil, model.NewCodedError(model.ErrorCodeCircuitOpen, http.StatusServiceUnavailable,
+			fmt.Sprintf("%s is temporarily unavailable", upstream)).WithCause(err)
+	}
+	if isTimeout(err) {
+		return 0, nil, model.NewCodedError(model.ErrorCodeUpstreamTimeout, http.StatusGatewayTimeout,
+			fmt.Sprintf("%s did not answer in time", upstream)).WithCause(err)
+	}
+	if err != nil {
+		return 0, nil, model.NewCodedError(model.ErrorCodeUpstreamUnavailable, http.StatusServiceUnavailable,
+			fmt.Sprintf("error sending request to %s", upstream)).WithCause(err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxUpstreamBodyBytes+1))
+	if err != nil {
+		return 0, nil, model.NewCodedError(model.ErrorCodeUpstreamError, http.StatusBadGateway,
+			fmt.Sprintf("error reading response from %s", upstream)).WithCause(err)
+	}
+	if len(body) > maxUpstreamBodyBytes {
+		return 0, nil, model.NewCodedError(model.ErrorCodeUpstreamError, http.StatusBadGateway,
+			fmt.Sprintf("response from %s is too large", upstream)).
+			WithCause(fmt.Errorf("body exceeds %d bytes", maxUpstreamBodyBytes))
+	}
+
+	return response.StatusCode, body, nil
+}
+
+func isTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// endpointError reports a misconfigured upstream URL.
+func endpointError(err error) *model.CustomError {
+	return model.NewCodedError(model.ErrorCodeInternal, http.StatusInternalServerError,
+		"error building endpoint").WithCause(err)
+}
+
+// unexpectedStatusError hides the upstream answer from clients; the status and
+// body are only kept as the cause.
+func unexpectedStatusError(statusCode int, upstream string, body []byte) *model.CustomError {
+	return upstreamStatusError(statusCode, upstream, fmt.Sprintf("unexpected error from %s", upstream),
+		fmt.Errorf("status %d: %s", statusCode, body))
+}
+
+// upstreamRejectionError reports an error the upstream explained in its body;
+// the status and explanation are kept as the cause only.
+func upstreamRejectionError(statusCode int, upstream, reason string) *model.CustomError {
+	return upstreamStatusError(statusCode, upstream, fmt.Sprintf("%s could not answer the request", upstream),
+		fmt.Errorf("status %d: %s", statusCode, reason))
+}
+
+// upstreamStatusError maps a non 200 answer to the status we answer with, as
+// weatherApiErrorStatuses does for WeatherAPI. An upstream 4xx reports our own
+// misconfiguration (key, quota, request), which must not reach clients as
+// theirs: it is a bad gateway. Throttling and upstream outages are reported as
+// unavailable.
+func upstreamStatusError(statusCode int, upstream, message string, cause error) *model.CustomError {
+	if statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError {
+		return model.NewCodedError(model.ErrorCodeUpstreamUnavailable, http.StatusServiceUnavailable,
+			fmt.Sprintf("%s is temporarily unavailable", upstream)).WithCause(cause)
+	}
+	return model.NewCodedError(model.ErrorCodeUpstreamError, http.StatusBadGateway, message).WithCause(cause)
+}
+
+func locationNotFoundError(city model.City) *model.CustomError {
+	return model.NewCodedError(model.ErrorCodeLocationNotFound, http.StatusNotFound,
+		fmt.Sprintf("can not find weather location for %s/%s", city.Name, city.State))
+}
+
+// notImplementedError is returned by providers for features their API lacks,
+// so a provider chain moves on to the next provider.
+func notImplementedError(upstream, feature string) *model.CustomError {
+	return model.NewCodedError(model.ErrorCodeNotImplemented, http.StatusNotImplemented,
+		fmt.Sprintf("%s does not support %s", upstream, feature))
+}
+
+func unmarshalError(upstream string, err error) *model.CustomError {
+	return model.NewCodedError(model.ErrorCodeUpstreamError, http.StatusBadGateway,
+		fmt.Sprintf("error unmarshalling response from %s", upstream)).WithCause(err)
+}
+
+// unexpectedZipCodeStatusError is returned by zip code providers for answers
+// they do not understand, as a 502 so the provider chain moves on.
+func unexpectedZipCodeStatusError(statusCode int, upstream string, body []byte) *model.CustomError {
+	return model.NewCodedError(model.ErrorCodeUpstreamError, http.StatusBadGateway,
+		fmt.Sprintf("unexpected response from %s", upstream)).
+		WithCause(fmt.Errorf("status %d: %s", statusCode, body))
+}
+
+func emptyCityError(upstream string) *model.CustomError {
+	return model.NewCodedError(model.ErrorCodeUpstreamError, http.StatusBadGateway,
+		fmt.Sprintf("city is empty in response from %s", upstream))
+}
+
+func zipCodeDigits(zipCode model.ZipCode) string {
+	return strings.ReplaceAll(zipCode.ToString(), "-", "")
+}
@@ -3,83 +3,72 @@ package service
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"io"
 	"net/http"
 	"time"
 
 	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
 	"github.com/Berchon/weather-cloud-run/internal/business/model"
-	"github.com/Berchon/weather-cloud-run/internal/infrastructure/configs"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/dto"
 )
 
 type viaCepService struct {
-	endpoint *config.Endpoint
+	settings EndpointSettings
 	client   config.HTTPDoer
 }
 
-func NewViaCepService(client config.HTTPDoer) gateway.ViaCepService {
+func NewViaCepService(client config.HTTPDoer, settings EndpointSettings) gateway.ViaCepService {
 	if client == nil {
 		client = config.NewHTTPClient(3 * time.Second)
 	}
 	return &viaCepService{
-		endpoint: config.NewEndpoint(),
+		settings: settings,
 		client:   client,
 	}
 }
 
-func (s *viaCepService) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*string, *model.CustomError) {
-	path := fmt.Sprintf(configs.GetViaCepPath(), zipCode)
-	ep := s.endpoint.
-		SetBaseURL(configs.GetViaCepBaseUrl()).
-		SetPath(path)
+func (s *viaCepService) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError) {
+	ep := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.Path, zipCode.ToString())
 
 	url, err := ep.Build()
 	if err != nil {
-		fmt.Println("Error building endpoint:", err)
-		return nil, model.NewCustomError(http.StatusInternalServerError,
-			fmt.Sprintf("Error building endpoint: %v", err))
+		return nil, endpointError(err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, model.NewCustomError(http.StatusInternalServerError, fmt.Sprintf("error creating request: %v", err))
-	}
-
-	response, err := s.client.Do(req)
-	if err != nil {
-		return nil, model.NewCustomError(http.StatusInternalServerError, fmt.Sprintf("error sending request: %v", err))
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "via cep")
+	if customErr != nil {
+		return nil, customErr
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode == http.StatusBadRequest {
-		return nil, model.NewCustomError(http.StatusUnprocessableEntity, "invalid zipcode")
+	if statusCode == http.StatusBadRequest {
+		return nil, model.NewInvalidZipCodeError()
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, model.NewCustomError(http.StatusInternalServerError,
-			fmt.Sprintf("error reading response. Status code not OK: Status code returned %v", response.StatusCode))
-	}
-
-	body, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, model.NewCustomError(http.StatusInternalServerError, fmt.Sprintf("error reading response: %v", err))
+	if statusCode != http.StatusOK {
+		return nil, unexpectedZipCodeStatusError(statusCode, "via cep", body)
 	}
 
 	var viaCepDto dto.ViaCepDto
 	if err := json.Unmarshal(body, &viaCepDto); err != nil {
-		return nil, model.NewCustomError(http.StatusInternalServerError, fmt.Sprintf("error unmarshalling response: %v", err))
+		return nil, unmarshalError("via cep", err)
 	}
 
 	if viaCepDto.Error == "true" {
-		return nil, model.NewCustomError(http.StatusNotFound, "can not find zipcode")
+		return nil, model.NewZipCodeNotFoundError()
 	}
 
 	if viaCepDto.City == "" {
-		return nil, model.NewCustomError(http.StatusInternalServerError, "city is empty in response")
+		return nil, emptyCityError("via cep")
 	}
 
-	return &viaCepDto.City, nil
+	return &model.Address{
+		ZipCode:      viaCepDto.ZipCode,
+		Street:       viaCepDto.Street,
+		Neighborhood: viaCepDto.Neighborhood,
+		City:         viaCepDto.City,
+		State:        viaCepDto.State,
+		IBGECode:     viaCepDto.IBGE,
+	}, nil
 }
@@ -3,10 +3,15 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 
-	"github.com/Berchon/weather-cloud-run/internal/infrastructure/configs"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
 	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
 	config "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
 	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
@@ -14,28 +19,28 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-func configureEnvironment() {
-	_ = configs.LoadConfig(".")
-	configs.SetViaCepBaseUrl("https://viacep.com.br")
-	configs.SetViaCepPath("/ws/%s/json")
+var viaCepSettings = servicepkg.EndpointSettings{
+	BaseURL: "https://viacep.com.br",
+	Path:    "/ws/%s/json",
 }
 
 func TestNewViaCepService_DefaultClient(t *testing.T) {
-	service := servicepkg.NewViaCepService(nil)
+	t.Parallel()
+
+	service := servicepkg.NewViaCepService(nil, viaCepSettings)
 	assert.NotNil(t, service)
 }
 func TestViaCepService_GetAddressByZipCode(t *testing.T) {
+	t.Parallel()
 
 	ctx := context.Background()
-	configureEnvironment()
 
 	t.Run("should return error when creating request with invalid URL", func(t *testing.T) {
-		originalBaseUrl := configs.GetViaCepBaseUrl()
-		configs.SetViaCepBaseUrl("http://[::1]:namedport") // invalid URL
-		defer configs.SetViaCepBaseUrl(originalBaseUrl)
+		settings := viaCepSettings
+		settings.BaseURL = "http://[::1]:namedport" // invalid URL
 
 		mockClient := configMock.NewMockHTTPDoer(t)
-		svc := servicepkg.NewViaCepService(mockClient)
+		svc := servicepkg.NewViaCepService(mockClient, settings)
 
 		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
 		assert.Error(t, err)
@@ -43,28 +48,57 @@ func TestViaCepService_GetAddressByZipCode(t *testing.T) {
 	})
 
 	t.Run("should return error when sending request fails", func(t *testing.T) {
-		configs.SetViaCepBaseUrl("https://viacep.com.br")
-		configs.SetViaCepPath("/ws/%s/json")
-
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(nil, errors.New("http failure"))
 
-		svc := servicepkg.NewViaCepService(mockClient)
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
 		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
 
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "http failure")
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamUnavailable, err.Code)
+			assert.Contains(t, err.Error(), "http failure")
+		}
 		mockClient.AssertExpectations(t)
 	})
 
-	t.Run("should return 422 when via cep returns status 400", func(t *testing.T) {
-		configs.SetViaCepBaseUrl("https://viacep.com.br")
-		configs.SetViaCepPath("/ws/%s/json")
+	t.Run("should return 503 when circuit breaker is open", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, config.ErrCircuitOpen)
+
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
+		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, "via cep is temporarily unavailable", err.Err)
+			assert.Equal(t, model.ErrorCodeCircuitOpen, err.Code)
+			assert.ErrorIs(t, err, config.ErrCircuitOpen)
+		}
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("should return 504 when via cep times out", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, context.DeadlineExceeded)
+
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
+		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusGatewayTimeout, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamTimeout, err.Code)
+			assert.Equal(t, "via cep did not answer in time", err.Err)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+		mockClient.AssertExpectations(t)
+	})
 
+	t.Run("should return 422 when via cep returns status 400", func(t *testing.T) {
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(http.StatusBadRequest, ""), nil)
 
-		svc := servicepkg.NewViaCepService(mockClient)
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
 		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
 
 		assert.Error(t, err)
@@ -74,34 +108,28 @@ func TestViaCepService_GetAddressByZipCode(t *testing.T) {
 		mockClient.AssertExpectations(t)
 	})
 
-	t.Run("should return 500 when via cep returns status different from 200 or 400", func(t *testing.T) {
-		configs.SetViaCepBaseUrl("https://viacep.com.br")
-		configs.SetViaCepPath("/ws/%s/json")
-
+	t.Run("should return 502 when via cep returns status different from 200 or 400", func(t *testing.T) {
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(http.StatusInternalServerError, ""), nil)
 
-		svc := servicepkg.NewViaCepService(mockClient)
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
 		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
 
 		assert.Error(t, err)
 		if assert.NotNil(t, err) {
-			assert.Equal(t, 500, err.StatusCode)
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
 		}
 		mockClient.AssertExpectations(t)
 	})
 
-	t.Run("should return 500 when reading response body fails", func(t *testing.T) {
-		configs.SetViaCepBaseUrl("https://viacep.com.br")
-		configs.SetViaCepPath("/ws/%s/json")
-
+	t.Run("should return 502 when reading response body fails", func(t *testing.T) {
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(&http.Response{
 			StatusCode: 200,
 			Body:       &config.ErrorReader{},
 		}, nil)
 
-		svc := servicepkg.NewViaCepService(mockClient)
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
 		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
 
 		assert.Error(t, err)
@@ -109,29 +137,24 @@ func TestViaCepService_GetAddressByZipCode(t *testing.T) {
 		mockClient.AssertExpectations(t)
 	})
 
-	t.Run("should return 500 when unmarshalling response body fails", func(t *testing.T) {
-		configs.SetViaCepBaseUrl("https://viacep.com.br")
-		configs.SetViaCepPath("/ws/%s/json")
-
+	t.Run("should return 502 when unmarshalling response body fails", func(t *testing.T) {
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, "{invalid"), nil)
 
-		svc := servicepkg.NewViaCepService(mockClient)
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
 		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unmarshalling")
+		assert.Equal(t, http.StatusBadGateway, err.StatusCode)
 		mockClient.AssertExpectations(t)
 	})
 
 	t.Run("should return 404 when via cep returns error field", func(t *testing.T) {
-		configs.SetViaCepBaseUrl("https://viacep.com.br")
-		configs.SetViaCepPath("/ws/%s/json")
-
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, `{"erro":"true"}`), nil)
 
-		svc := servicepkg.NewViaCepService(mockClient)
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
 		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
 
 		assert.Error(t, err)
@@ -141,36 +164,80 @@ func TestViaCepService_GetAddressByZipCode(t *testing.T) {
 		mockClient.AssertExpectations(t)
 	})
 
-	t.Run("should return 500 when city is empty in response", func(t *testing.T) {
-		configs.SetViaCepBaseUrl("https://viacep.com.br")
-		configs.SetViaCepPath("/ws/%s/json")
-
+	t.Run("should return 502 when city is empty in response", func(t *testing.T) {
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, `{"cep":"12345-678","localidade":""}`), nil)
 
-		svc := servicepkg.NewViaCepService(mockClient)
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
 		_, err := svc.GetAddressByZipCode(ctx, "12345-678")
 
 		assert.Error(t, err)
 		if assert.NotNil(t, err) {
-			assert.Equal(t, 500, err.StatusCode)
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
 		}
 		mockClient.AssertExpectations(t)
 	})
 
-	t.Run("should return valid city when via cep returns success", func(t *testing.T) {
-		configs.SetViaCepBaseUrl("https://viacep.com.br")
-		configs.SetViaCepPath("/ws/%s/json")
-
+	t.Run("should return address when via cep returns success", func(t *testing.T) {
 		mockClient := configMock.NewMockHTTPDoer(t)
-		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, `{"cep":"12345-678","localidade":"Porto Alegre"}`), nil)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, `{"cep":"12345-678","logradouro":"Rua dos Andradas","bairro":"Centro Histórico","localidade":"Porto Alegre","uf":"RS","ibge":"4314902"}`), nil)
 
-		svc := servicepkg.NewViaCepService(mockClient)
-		city, err := svc.GetAddressByZipCode(ctx, "12345-678")
+		svc := servicepkg.NewViaCepService(mockClient, viaCepSettings)
+		address, err := svc.GetAddressByZipCode(ctx, "12345-678")
 
 		assert.Nil(t, err)
-		assert.NotNil(t, city)
-		assert.Equal(t, "Porto Alegre", *city)
+		assert.NotNil(t, address)
+		assert.Equal(t, model.Address{
+			ZipCode:      "12345-678",
+			Street:       "Rua dos Andradas",
+			Neighborhood: "Centro Histórico",
+			City:         "Porto Alegre",
+			State:        "RS",
+			IBGECode:     "4314902",
+		}, *address)
 		mockClient.AssertExpectations(t)
 	})
 }
+
+func TestViaCepService_ParallelLookups(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		segments := strings.Split(r.URL.EscapedPath(), "/")
+		if len(segments) != 4 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		zipCode, _ := url.PathUnescape(segments[2])
+		fmt.Fprintf(w, `{"cep":%q,"localidade":"City %s","uf":"SP"}`, zipCode, zipCode)
+	}))
+	t.Cleanup(server.Close)
+	svc := servicepkg.NewViaCepService(server.Client(), servicepkg.EndpointSettings{BaseURL: server.URL, Path: "/ws/%s/json"})
+
+	t.Run("should answer each concurrent lookup with its own zip code", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				zipCode := fmt.Sprintf("%08d", i)
+
+				address, err := svc.GetAddressByZipCode(context.Background(), model.ZipCode(zipCode))
+
+				if assert.Nil(t, err) {
+					assert.Equal(t, zipCode, address.ZipCode)
+					assert.Equal(t, "City "+zipCode, address.City)
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
+
+	t.Run("should escape the zip code in the path", func(t *testing.T) {
+		address, err := svc.GetAddressByZipCode(context.Background(), "01001/../x")
+
+		if assert.Nil(t, err) {
+			assert.Equal(t, "01001/../x", address.ZipCode)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+type WeatherProvider = Provider[gateway.WeatherService]
+
+type WeatherProviderChain interface {
+	gateway.WeatherService
+	Stats() map[string]ProviderStats
+}
+
+type weatherProviderChain struct {
+	*providerChain[gateway.WeatherService]
+}
+
+// NewWeatherProviderChain tries providers in order, failing over when one is
+// down or refuses to serve us, which providers report as 502 or 503.
+// Providers lacking the feature (501) are skipped without counting as failed,
+// and their 501 is returned only when no provider supports it.
+// Any other answer, such as an unknown location, is returned as is. A nil
+// logger logs nowhere.
+func NewWeatherProviderChain(logger *slog.Logger, providers ...WeatherProvider) WeatherProviderChain {
+	return &weatherProviderChain{
+		providerChain: newProviderChain("weather", logger, classifyWeatherProviderError, providers),
+	}
+}
+
+func (c *weatherProviderChain) GetWeatherByCity(ctx context.Context, city model.City) (*model.Weather, *model.CustomError) {
+	return failOver(ctx, c.providerChain, func(service gateway.WeatherService) (*model.Weather, *model.CustomError) {
+		return service.GetWeatherByCity(ctx, city)
+	})
+}
+
+func (c *weatherProviderChain) GetConditionsByCity(ctx context.Context, city model.City) (*model.WeatherConditions, *model.CustomError) {
+	return failOver(ctx, c.providerChain, func(service gateway.WeatherService) (*model.WeatherConditions, *model.CustomError) {
+		return service.GetConditionsByCity(ctx, city)
+	})
+}
+
+func (c *weatherProviderChain) GetForecastByCity(ctx context.Context, city model.City, days int) (*model.WeatherForecast, *model.CustomError) {
+	return failOver(ctx, c.providerChain, func(service gateway.WeatherService) (*model.WeatherForecast, *model.CustomError) {
+		return service.GetForecastByCity(ctx, city, days)
+	})
+}
+
+func (c *weatherProviderChain) GetHistoryByCity(ctx context.Context, city model.City, date time.Time) (*model.WeatherForecast, *model.CustomError) {
+	return failOver(ctx, c.providerChain, func(service gateway.WeatherService) (*model.WeatherForecast, *model.CustomError) {
+		return service.GetHistoryByCity(ctx, city, date)
+	})
+}
+
+func (c *weatherProviderChain) GetAirQualityByCity(ctx context.Context, city model.City) (*model.AirQualityReport, *model.CustomError) {
+	return failOver(ctx, c.providerChain, func(service gateway.WeatherService) (*model.AirQualityReport, *model.CustomError) {
+		return service.GetAirQualityByCity(ctx, city)
+	})
+}
+
+func classifyWeatherProviderError(err *model.CustomError) providerOutcome {
+	switch {
+	case err.Code == model.ErrorCodeNotImplemented:
+		return providerSkipped
+	case err.StatusCode >= http.StatusInternalServerError:
+		return providerFailed
+	default:
+		return providerAnswered
+	}
+}
@@ -0,0 +1,171 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWeatherProviderChain_GetWeatherByCity(t *testing.T) {
+	ctx := context.Background()
+	city := model.City{Name: "Porto Alegre", State: "RS"}
+
+	t.Run("should return first provider answer", func(t *testing.T) {
+		weather := &model.Weather{Celsius: 21.0}
+		first := serviceMock.NewMockWeatherService(t)
+		first.On("GetWeatherByCity", mock.Anything, city).Return(weather, nil).Once()
+		second := serviceMock.NewMockWeatherService(t)
+
+		chain := servicepkg.NewWeatherProviderChain(nil,
+			servicepkg.WeatherProvider{Name: "weatherapi", Service: first},
+			servicepkg.WeatherProvider{Name: "openmeteo", Service: second},
+		)
+		result, err := chain.GetWeatherByCity(ctx, city)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 21.0, result.Celsius)
+		assert.Equal(t, uint64(1), chain.Stats()["weatherapi"].Answered)
+	})
+
+	t.Run("should fail over on bad gateway, unavailable and timeout errors", func(t *testing.T) {
+		for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+			weather := &model.Weather{Celsius: 18.5}
+			first := serviceMock.NewMockWeatherService(t)
+			first.On("GetWeatherByCity", mock.Anything, city).
+				Return(nil, model.NewCustomError(status, "first failure")).Once()
+			second := serviceMock.NewMockWeatherService(t)
+			second.On("GetWeatherByCity", mock.Anything, city).Return(weather, nil).Once()
+
+			chain := servicepkg.NewWeatherProviderChain(nil,
+				servicepkg.WeatherProvider{Name: "weatherapi", Service: first},
+				servicepkg.WeatherProvider{Name: "openmeteo", Service: second},
+			)
+			result, err := chain.GetWeatherByCity(ctx, city)
+
+			assert.Nil(t, err)
+			assert.Equal(t, 18.5, result.Celsius)
+			stats := chain.Stats()
+			assert.Equal(t, servicepkg.ProviderStats{Failed: 1}, stats["weatherapi"])
+			assert.Equal(t, servicepkg.ProviderStats{Answered: 1}, stats["openmeteo"])
+		}
+	})
+
+	t.Run("should not fail over when location is not found", func(t *testing.T) {
+		first := serviceMock.NewMockWeatherService(t)
+		first.On("GetWeatherByCity", mock.Anything, city).
+			Return(nil, model.NewCustomError(http.StatusNotFound, "can not find weather location")).Once()
+		second := serviceMock.NewMockWeatherService(t)
+
+		chain := servicepkg.NewWeatherProviderChain(nil,
+			servicepkg.WeatherProvider{Name: "weatherapi", Service: first},
+			servicepkg.WeatherProvider{Name: "openmeteo", Service: second},
+		)
+		_, err := chain.GetWeatherByCity(ctx, city)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		}
+	})
+
+	t.Run("should return last error when every provider fails", func(t *testing.T) {
+		first := serviceMock.NewMockWeatherService(t)
+		first.On("GetWeatherByCity", mock.Anything, city).
+			Return(nil, model.NewCustomError(http.StatusInternalServerError, "first failure")).Once()
+		second := serviceMock.NewMockWeatherService(t)
+		second.On("GetWeatherByCity", mock.Anything, city).
+			Return(nil, model.NewCustomError(http.StatusServiceUnavailable, "second failure")).Once()
+
+		chain := servicepkg.NewWeatherProviderChain(nil,
+			servicepkg.WeatherProvider{Name: "weatherapi", Service: first},
+			servicepkg.WeatherProvider{Name: "openmeteo", Service: second},
+		)
+		_, err := chain.GetWeatherByCity(ctx, city)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, "second failure", err.Error())
+		}
+	})
+
+	t.Run("should return error when no provider is configured", func(t *testing.T) {
+		chain := servicepkg.NewWeatherProviderChain(nil)
+		_, err := chain.GetWeatherByCity(ctx, city)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		}
+	})
+}
+
+func TestWeatherProviderChain_GetForecastByCity(t *testing.T) {
+	ctx := context.Background()
+	city := model.City{Name: "Porto Alegre", State: "RS"}
+
+	t.Run("should fail over providers without forecast support", func(t *testing.T) {
+		forecast := &model.WeatherForecast{Days: []model.WeatherForecastDay{{Date: "2025-07-01"}}}
+		first := serviceMock.NewMockWeatherService(t)
+		first.On("GetForecastByCity", mock.Anything, city, 2).
+			Return(nil, model.NewCustomError(http.StatusNotImplemented, "open meteo does not support forecast")).Once()
+		second := serviceMock.NewMockWeatherService(t)
+		second.On("GetForecastByCity", mock.Anything, city, 2).Return(forecast, nil).Once()
+
+		chain := servicepkg.NewWeatherProviderChain(nil,
+			servicepkg.WeatherProvider{Name: "openmeteo", Service: first},
+			servicepkg.WeatherProvider{Name: "weatherapi", Service: second},
+		)
+		result, err := chain.GetForecastByCity(ctx, city, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, forecast, result)
+		assert.Equal(t, servicepkg.ProviderStats{}, chain.Stats()["openmeteo"])
+		assert.Equal(t, servicepkg.ProviderStats{Answered: 1}, chain.Stats()["weatherapi"])
+	})
+
+	t.Run("should not let a provider without forecast support hide an outage", func(t *testing.T) {
+		first := serviceMock.NewMockWeatherService(t)
+		first.On("GetForecastByCity", mock.Anything, city, 2).
+			Return(nil, model.NewCustomError(http.StatusServiceUnavailable, "weather api is temporarily unavailable")).Once()
+		second := serviceMock.NewMockWeatherService(t)
+		second.On("GetForecastByCity", mock.Anything, city, 2).
+			Return(nil, model.NewCustomError(http.StatusNotImplemented, "open meteo does not support forecast")).Once()
+
+		chain := servicepkg.NewWeatherProviderChain(nil,
+			servicepkg.WeatherProvider{Name: "weatherapi", Service: first},
+			servicepkg.WeatherProvider{Name: "openmeteo", Service: second},
+		)
+		_, err := chain.GetForecastByCity(ctx, city, 2)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+		}
+		assert.Equal(t, servicepkg.ProviderStats{Failed: 1}, chain.Stats()["weatherapi"])
+		assert.Equal(t, servicepkg.ProviderStats{}, chain.Stats()["openmeteo"])
+	})
+
+	t.Run("should return 501 when no provider supports forecast", func(t *testing.T) {
+		first := serviceMock.NewMockWeatherService(t)
+		first.On("GetForecastByCity", mock.Anything, city, 2).
+			Return(nil, model.NewCustomError(http.StatusNotImplemented, "open meteo does not support forecast")).Once()
+		second := serviceMock.NewMockWeatherService(t)
+		second.On("GetForecastByCity", mock.Anything, city, 2).
+			Return(nil, model.NewCustomError(http.StatusNotImplemented, "open weather map does not support forecast")).Once()
+
+		chain := servicepkg.NewWeatherProviderChain(nil,
+			servicepkg.WeatherProvider{Name: "openmeteo", Service: first},
+			servicepkg.WeatherProvider{Name: "openweathermap", Service: second},
+		)
+		_, err := chain.GetForecastByCity(ctx, city, 2)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeNotImplemented, err.Code)
+		}
+		assert.Equal(t, servicepkg.ProviderStats{}, chain.Stats()["openmeteo"])
+	})
+}
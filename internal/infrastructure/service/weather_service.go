@@ -4,82 +4,314 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
 	"github.com/Berchon/weather-cloud-run/internal/business/model"
-	"github.com/Berchon/weather-cloud-run/internal/infrastructure/configs"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/service/dto"
 )
 
+// WeatherAPISettings locates the WeatherAPI endpoints and carries the key
+// sent with every call.
+type WeatherAPISettings struct {
+	BaseURL      string
+	CurrentPath  string
+	ForecastPath string
+	HistoryPath  string
+	APIKey       string
+}
+
 type weatherService struct {
-	endpoint *config.Endpoint
+	settings WeatherAPISettings
 	client   config.HTTPDoer
 }
 
-func NewWeatherService(client config.HTTPDoer) gateway.WeatherService {
+func NewWeatherService(client config.HTTPDoer, settings WeatherAPISettings) gateway.WeatherService {
 	if client == nil {
 		client = config.NewHTTPClient(3 * time.Second)
 	}
 
 	return &weatherService{
-		endpoint: config.NewEndpoint(),
+		settings: settings,
 		client:   client,
 	}
 }
 
-func (s *weatherService) GetWeatherByCity(ctx context.Context, city string) (*float64, *model.CustomError) {
-	ep := s.endpoint.
-		SetBaseURL(configs.GetWeatherBaseUrl()).
-		SetPath(configs.GetWeatherPath()).
-		AddQueryParam("key", configs.GetWeatherAPIKey()).
-		AddQueryParam("q", city).
+func (s *weatherService) GetWeatherByCity(ctx context.Context, city model.City) (*model.Weather, *model.CustomError) {
+	weatherDto, location, customErr := s.current(ctx, city)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	return &model.Weather{
+		Celsius:  weatherDto.Current.TempC,
+		Location: *location,
+	}, nil
+}
+
+func (s *weatherService) GetConditionsByCity(ctx context.Context, city model.City) (*model.WeatherConditions, *model.CustomError) {
+	weatherDto, location, customErr := s.current(ctx, city)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	current := weatherDto.Current
+	return &model.WeatherConditions{
+		Celsius:          current.TempC,
+		FeelsLikeCelsius: current.FeelsLikeC,
+		Humidity:         current.Humidity,
+		WindKph:          current.WindKph,
+		WindDegree:       current.WindDegree,
+		WindDirection:    current.WindDir,
+		PressureMb:       current.PressureMb,
+		PrecipitationMm:  current.PrecipMm,
+		UV:               current.UV,
+		ConditionText:    current.Condition.Text,
+		ConditionIcon:    current.Condition.Icon,
+		LastUpdated:      current.LastUpdated,
+		Location:         *location,
+	}, nil
+}
+
+// current queries current.json, which answers both the temperature and the
+// extended conditions.
+func (s *weatherService) current(ctx context.Context, city model.City) (*dto.WeatherDto, *model.Location, *model.CustomError) {
+	ep := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.CurrentPath).
+		AddQueryParam("key", s.settings.APIKey).
+		AddQueryParam("q", weatherApiQuery(city)).
 		AddQueryParam("aqi", "no")
 
 	url, err := ep.Build()
 	if err != nil {
-		fmt.Println("Error building endpoint:", err)
-		return nil, model.NewCustomError(http.StatusInternalServerError,
-			fmt.Sprintf("Error building endpoint: %v", err))
+		return nil, nil, endpointError(err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, model.NewCustomError(http.StatusInternalServerError,
-			fmt.Sprintf("error creating request: %v", err))
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "weather api")
+	if customErr != nil {
+		return nil, nil, customErr
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, nil, weatherApiError(statusCode, body, city)
+	}
+
+	var weatherDto dto.WeatherDto
+	if err := json.Unmarshal(body, &weatherDto); err != nil {
+		return nil, nil, unmarshalError("weather api", err)
+	}
+
+	location, customErr := weatherApiLocation(weatherDto.Location, city)
+	if customErr != nil {
+		return nil, nil, customErr
 	}
+	return &weatherDto, location, nil
+}
+
+func (s *weatherService) GetForecastByCity(ctx context.Context, city model.City, days int) (*model.WeatherForecast, *model.CustomError) {
+	ep := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.ForecastPath).
+		AddQueryParam("days", strconv.Itoa(days)).
+		AddQueryParam("alerts", "no")
+	return s.forecastDays(ctx, ep, city)
+}
 
-	resp, err := s.client.Do(req)
+// GetHistoryByCity queries history.json, which answers a single day in the
+// same shape as forecast.json.
+func (s *weatherService) GetHistoryByCity(ctx context.Context, city model.City, date time.Time) (*model.WeatherForecast, *model.CustomError) {
+	ep := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.HistoryPath).
+		AddQueryParam("dt", date.Format("2006-01-02"))
+	return s.forecastDays(ctx, ep, city)
+}
+
+// GetAirQualityByCity queries forecast.json for today only, the one endpoint
+// that answers both the air quality (aqi=yes) and the alerts (alerts=yes).
+func (s *weatherService) GetAirQualityByCity(ctx context.Context, city model.City) (*model.AirQualityReport, *model.CustomError) {
+	url, err := config.NewEndpoint().
+		SetBaseURL(s.settings.BaseURL).
+		SetPath(s.settings.ForecastPath).
+		AddQueryParam("key", s.settings.APIKey).
+		AddQueryParam("q", weatherApiQuery(city)).
+		AddQueryParam("days", "1").
+		AddQueryParam("aqi", "yes").
+		AddQueryParam("alerts", "yes").
+		Build()
 	if err != nil {
-		return nil, model.NewCustomError(http.StatusInternalServerError,
-			fmt.Sprintf("error sending request: %v", err))
+		return nil, endpointError(err)
+	}
+
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "weather api")
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	if statusCode != http.StatusOK {
+		return nil, weatherApiError(statusCode, body, city)
+	}
+
+	var reportDto dto.WeatherAirQualityReportDto
+	if err := json.Unmarshal(body, &reportDto); err != nil {
+		return nil, unmarshalError("weather api", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	location, customErr := weatherApiLocation(reportDto.Location, city)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	airQuality := reportDto.Current.AirQuality
+	report := &model.AirQualityReport{
+		Location: *location,
+		AirQuality: model.AirQuality{
+			CO:           airQuality.CO,
+			NO2:          airQuality.NO2,
+			O3:           airQuality.O3,
+			SO2:          airQuality.SO2,
+			PM2_5:        airQuality.PM2_5,
+			PM10:         airQuality.PM10,
+			USEPAIndex:   airQuality.USEPAIndex,
+			GBDefraIndex: airQuality.GBDefraIndex,
+		}.Classify(),
+		Alerts: []model.WeatherAlert{},
+	}
+	for _, alert := range reportDto.Alerts.Alert {
+		report.Alerts = append(report.Alerts, model.WeatherAlert{
+			Headline:    alert.Headline,
+			Event:       alert.Event,
+			Category:    alert.Category,
+			Severity:    alert.Severity,
+			Urgency:     alert.Urgency,
+			Areas:       alert.Areas,
+			Effective:   alert.Effective,
+			Expires:     alert.Expires,
+			Description: alert.Desc,
+			Instruction: alert.Instruction,
+		})
+	}
+	return report, nil
+}
+
+func (s *weatherService) forecastDays(ctx context.Context, ep config.Endpoint, city model.City) (*model.WeatherForecast, *model.CustomError) {
+	url, err := ep.
+		AddQueryParam("key", s.settings.APIKey).
+		AddQueryParam("q", weatherApiQuery(city)).
+		AddQueryParam("aqi", "no").
+		Build()
 	if err != nil {
-		return nil, model.NewCustomError(http.StatusInternalServerError,
-			fmt.Sprintf("error reading response: %v", err))
+		return nil, endpointError(err)
+	}
+
+	statusCode, body, customErr := sendGetRequest(ctx, s.client, url, "weather api")
+	if customErr != nil {
+		return nil, customErr
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		var apiErr dto.WeatherErrorDto
-		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error.Message != "" {
-			return nil, model.NewCustomError(resp.StatusCode,
-				fmt.Sprintf("Error code: %d. Description: %s", apiErr.Error.Code, apiErr.Error.Message))
+	if statusCode != http.StatusOK {
+		return nil, weatherApiError(statusCode, body, city)
+	}
+
+	var forecastDto dto.WeatherForecastDto
+	if err := json.Unmarshal(body, &forecastDto); err != nil {
+		return nil, unmarshalError("weather api", err)
+	}
+
+	location, customErr := weatherApiLocation(forecastDto.Location, city)
+	if customErr != nil {
+		return nil, customErr
+	}
+
+	forecast := &model.WeatherForecast{Location: *location}
+	for _, forecastDay := range forecastDto.Forecast.ForecastDay {
+		day := model.WeatherForecastDay{
+			Date:       forecastDay.Date,
+			MinCelsius: forecastDay.Day.MinTempC,
+			MaxCelsius: forecastDay.Day.MaxTempC,
+			AvgCelsius: forecastDay.Day.AvgTempC,
+		}
+		for _, hour := range forecastDay.Hour {
+			day.Hours = append(day.Hours, model.WeatherForecastHour{Time: hour.Time, Celsius: hour.TempC})
 		}
-		return nil, model.NewCustomError(resp.StatusCode,
-			fmt.Sprintf("unexpected error from weather api: %s", string(body)))
+		forecast.Days = append(forecast.Days, day)
 	}
+	return forecast, nil
+}
 
-	var weatherDto dto.WeatherDto
-	if err := json.Unmarshal(body, &weatherDto); err != nil {
-		return nil, model.NewCustomError(http.StatusInternalServerError,
-			fmt.Sprintf("error unmarshalling response: %v", err))
+// weatherApiLocation rejects locations WeatherAPI matched outside Brazil or in
+// another state than the one asked for.
+func weatherApiLocation(locationDto dto.WeatherLocationDto, city model.City) (*model.Location, *model.CustomError) {
+	if !isBrazil(locationDto.Country) || !regionMatchesState(locationDto.Region, city.State) {
+		return nil, locationNotFoundError(city)
 	}
 
-	return &weatherDto.Current.TempC, nil
+	return &model.Location{
+		Name:      locationDto.Name,
+		Region:    locationDto.Region,
+		Country:   locationDto.Country,
+		Latitude:  locationDto.Latitude,
+		Longitude: locationDto.Longitude,
+		LocalTime: locationDto.LocalTime,
+	}, nil
+}
+
+// weatherApiErrorStatuses maps WeatherAPI error codes to the status we answer
+// with. WeatherAPI reports our own misconfiguration (missing, invalid or
+// disabled key, malformed request) as 4xx, which must not reach clients as
+// theirs: it is a bad gateway. Codes absent from the table are treated the same.
+var weatherApiErrorStatuses = map[int]int{
+	1002: http.StatusBadGateway,         // API key not provided
+	1003: http.StatusBadGateway,         // q parameter not provided
+	1005: http.StatusBadGateway,         // API request url is invalid
+	1006: http.StatusNotFound,           // No location found matching q
+	2006: http.StatusBadGateway,         // API key provided is invalid
+	2007: http.StatusServiceUnavailable, // API key has exceeded the monthly quota
+	2008: http.StatusBadGateway,         // API key has been disabled
+	9999: http.StatusServiceUnavailable, // Internal application error
+}
+
+// weatherApiError translates a non 200 answer from WeatherAPI into a domain
+// error; the upstream status and message are only kept as the cause.
+func weatherApiError(statusCode int, body []byte, city model.City) *model.CustomError {
+	var apiErr dto.WeatherErrorDto
+	if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Error.Code == 0 {
+		return model.NewCodedError(model.ErrorCodeUpstreamError, http.StatusBadGateway, "unexpected error from weather api").
+			WithCause(fmt.Errorf("status %d: %s", statusCode, body))
+	}
+
+	cause := fmt.Errorf("status %d, error code %d: %s", statusCode, apiErr.Error.Code, apiErr.Error.Message)
+	status, ok := weatherApiErrorStatuses[apiErr.Error.Code]
+	if !ok {
+		status = http.StatusBadGateway
+	}
+	switch status {
+	case http.StatusNotFound:
+		return locationNotFoundError(city).WithCause(cause)
+	case http.StatusServiceUnavailable:
+		return model.NewCodedError(model.ErrorCodeUpstreamUnavailable, status, "weather api is temporarily unavailable").
+			WithCause(cause)
+	default:
+		return model.NewCodedError(model.ErrorCodeUpstreamError, status, "weather api could not answer the request").
+			WithCause(cause)
+	}
+}
+
+// weatherApiQuery builds the q parameter: coordinates when known, otherwise
+// the city qualified by state and country so homonyms in other states or
+// countries are not picked.
+func weatherApiQuery(city model.City) string {
+	if city.Coordinates != nil {
+		return fmt.Sprintf("%s,%s",
+			strconv.FormatFloat(city.Coordinates.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(city.Coordinates.Longitude, 'f', -1, 64))
+	}
+	if state := stateName(city.State); state != "" {
+		return fmt.Sprintf("%s, %s, Brazil", city.Name, state)
+	}
+	return fmt.Sprintf("%s, Brazil", city.Name)
 }
@@ -1,115 +1,494 @@
 package service_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
-	"github.com/Berchon/weather-cloud-run/internal/infrastructure/configs"
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/logging"
 	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
 	config "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config"
 	configMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/config/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func configureWeatherEnvironment() {
-	_ = configs.LoadConfig(".")
-	configs.SetWeatherBaseUrl("https://api.weather.com")
-	configs.SetWeatherPath("/v1/current.json")
-	configs.SetWeatherAPIKey("test-key")
+var weatherSettings = servicepkg.WeatherAPISettings{
+	BaseURL:      "https://api.weather.com",
+	CurrentPath:  "/v1/current.json",
+	ForecastPath: "/v1/forecast.json",
+	HistoryPath:  "/v1/history.json",
+	APIKey:       "test-key",
 }
 
 func TestNewWeatherService_DefaultClient(t *testing.T) {
-	service := servicepkg.NewWeatherService(nil)
+	t.Parallel()
+
+	service := servicepkg.NewWeatherService(nil, weatherSettings)
 	assert.NotNil(t, service)
 }
 
 func TestWeatherService_GetWeatherByCity(t *testing.T) {
+	t.Parallel()
+
 	ctx := context.Background()
-	configureWeatherEnvironment()
 
 	t.Run("should return error when building endpoint fails", func(t *testing.T) {
-		configs.SetWeatherBaseUrl("://invalid-url")
+		settings := weatherSettings
+		settings.BaseURL = "://invalid-url"
 		mockClient := configMock.NewMockHTTPDoer(t)
-		svc := servicepkg.NewWeatherService(mockClient)
-		_, err := svc.GetWeatherByCity(ctx, "Porto Alegre")
+		svc := servicepkg.NewWeatherService(mockClient, settings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid baseURL")
 	})
 
 	t.Run("should return error when sending request fails", func(t *testing.T) {
-		configureWeatherEnvironment()
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(nil, errors.New("http failure"))
-		svc := servicepkg.NewWeatherService(mockClient)
-		_, err := svc.GetWeatherByCity(ctx, "Porto Alegre")
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "http failure")
 		mockClient.AssertExpectations(t)
 	})
 
+	t.Run("should never expose the api key when the request fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+		settings := weatherSettings
+		settings.BaseURL = server.URL
+		var logs bytes.Buffer
+		logger, logErr := logging.New(&logs, logging.Settings{Format: "json"})
+		require.NoError(t, logErr)
+		svc := servicepkg.NewWeatherService(&http.Client{}, settings)
+
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+		require.NotNil(t, err)
+		logger.Error("weather provider failed", "error", err.Error())
+		body, jsonErr := json.Marshal(err)
+		require.NoError(t, jsonErr)
+
+		assert.Contains(t, err.Error(), "key=REDACTED")
+		assert.NotContains(t, err.Error(), "test-key")
+		assert.NotContains(t, string(body), "test-key")
+		assert.NotContains(t, logs.String(), "test-key")
+	})
+
+	t.Run("should still detect timeouts once the api key is redacted", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, &url.Error{Op: "Get",
+			URL: "https://api.weather.com/v1/current.json?key=test-key", Err: context.DeadlineExceeded})
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusGatewayTimeout, err.StatusCode)
+			assert.NotContains(t, err.Error(), "test-key")
+		}
+	})
+
+	t.Run("should return 503 when circuit breaker is open", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(nil, config.ErrCircuitOpen)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeCircuitOpen, err.Code)
+			assert.Equal(t, "weather api is temporarily unavailable", err.Err)
+			assert.ErrorIs(t, err, config.ErrCircuitOpen)
+		}
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("should return 502 when the response body is too large", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, strings.Repeat(" ", 1<<20+1)), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeUpstreamError, err.Code)
+			assert.Equal(t, "response from weather api is too large", err.Err)
+		}
+		mockClient.AssertExpectations(t)
+	})
+
 	t.Run("should return error when reading response body fails", func(t *testing.T) {
-		configureWeatherEnvironment()
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: 200, Body: &config.ErrorReader{}}, nil)
-		svc := servicepkg.NewWeatherService(mockClient)
-		_, err := svc.GetWeatherByCity(ctx, "Porto Alegre")
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "error reading response")
 		mockClient.AssertExpectations(t)
 	})
 
-	t.Run("should return error when weather api returns error json", func(t *testing.T) {
-		configureWeatherEnvironment()
-		apiErr := `{"error":{"code":1006,"message":"No matching location found."}}`
-		mockClient := configMock.NewMockHTTPDoer(t)
-		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(400, apiErr), nil)
-		svc := servicepkg.NewWeatherService(mockClient)
-		_, err := svc.GetWeatherByCity(ctx, "InvalidCity")
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "No matching location found.")
-		mockClient.AssertExpectations(t)
+	t.Run("should map weather api error codes to domain errors", func(t *testing.T) {
+		cases := []struct {
+			apiCode    int
+			apiStatus  int
+			message    string
+			wantStatus int
+			wantCode   model.ErrorCode
+		}{
+			{1002, http.StatusUnauthorized, "API key is invalid or not provided.", http.StatusBadGateway, model.ErrorCodeUpstreamError},
+			{1003, http.StatusBadRequest, "Parameter 'q' not provided.", http.StatusBadGateway, model.ErrorCodeUpstreamError},
+			{1005, http.StatusBadRequest, "API request url is invalid.", http.StatusBadGateway, model.ErrorCodeUpstreamError},
+			{1006, http.StatusBadRequest, "No matching location found.", http.StatusNotFound, model.ErrorCodeLocationNotFound},
+			{2006, http.StatusUnauthorized, "API key provided is invalid.", http.StatusBadGateway, model.ErrorCodeUpstreamError},
+			{2007, http.StatusForbidden, "API key has exceeded calls per month quota.", http.StatusServiceUnavailable, model.ErrorCodeUpstreamUnavailable},
+			{2008, http.StatusForbidden, "API key has been disabled.", http.StatusBadGateway, model.ErrorCodeUpstreamError},
+			{9999, http.StatusBadRequest, "Internal application error.", http.StatusServiceUnavailable, model.ErrorCodeUpstreamUnavailable},
+			{1234, http.StatusBadRequest, "Some new error.", http.StatusBadGateway, model.ErrorCodeUpstreamError},
+		}
+		for _, c := range cases {
+			t.Run(strconv.Itoa(c.apiCode), func(t *testing.T) {
+				apiErr := fmt.Sprintf(`{"error":{"code":%d,"message":%q}}`, c.apiCode, c.message)
+				mockClient := configMock.NewMockHTTPDoer(t)
+				mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(c.apiStatus, apiErr), nil)
+				svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+				_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+				if assert.NotNil(t, err) {
+					assert.Equal(t, c.wantStatus, err.StatusCode)
+					assert.Equal(t, c.wantCode, err.Code)
+					assert.NotContains(t, err.Err, c.message)
+					assert.Contains(t, err.Error(), c.message)
+				}
+				mockClient.AssertExpectations(t)
+			})
+		}
 	})
 
-	t.Run("should return error when weather api returns unexpected error", func(t *testing.T) {
-		configureWeatherEnvironment()
+	t.Run("should return 502 when weather api returns unexpected error", func(t *testing.T) {
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(500, "unexpected error"), nil)
-		svc := servicepkg.NewWeatherService(mockClient)
-		_, err := svc.GetWeatherByCity(ctx, "Porto Alegre")
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "unexpected error from weather api")
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadGateway, err.StatusCode)
+			assert.Equal(t, "unexpected error from weather api", err.Err)
+		}
 		mockClient.AssertExpectations(t)
 	})
 
 	t.Run("should return error when unmarshalling response body fails", func(t *testing.T) {
-		configureWeatherEnvironment()
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, "{invalid"), nil)
-		svc := servicepkg.NewWeatherService(mockClient)
-		_, err := svc.GetWeatherByCity(ctx, "Porto Alegre")
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "unmarshalling")
 		mockClient.AssertExpectations(t)
 	})
 
 	t.Run("should return valid temperatures when weather api returns success", func(t *testing.T) {
-		configureWeatherEnvironment()
 		weatherResp := map[string]interface{}{
-			"location": map[string]interface{}{"name": "Porto Alegre"},
-			"current":  map[string]interface{}{"temp_c": 25.0},
+			"location": map[string]interface{}{
+				"name": "Porto Alegre", "region": "Rio Grande do Sul", "country": "Brazil",
+				"lat": -30.03, "lon": -51.2, "localtime": "2025-07-01 14:30",
+			},
+			"current": map[string]interface{}{"temp_c": 25.0},
 		}
 		body, _ := json.Marshal(weatherResp)
 		mockClient := configMock.NewMockHTTPDoer(t)
 		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, string(body)), nil)
-		svc := servicepkg.NewWeatherService(mockClient)
-		result, err := svc.GetWeatherByCity(ctx, "Porto Alegre")
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		result, err := svc.GetWeatherByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
-		assert.InDelta(t, 25.0, *result, 0.01)
+		assert.InDelta(t, 25.0, result.Celsius, 0.01)
+		assert.Equal(t, model.Location{
+			Name: "Porto Alegre", Region: "Rio Grande do Sul", Country: "Brazil",
+			Latitude: -30.03, Longitude: -51.2, LocalTime: "2025-07-01 14:30",
+		}, result.Location)
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("should qualify the query with state and country", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Query().Get("q") == "Bom Jesus, Piauí, Brazil"
+		})).Return(config.NewTestResponse(200,
+			`{"location":{"name":"Bom Jesus","region":"Piaui","country":"Brazil"},"current":{"temp_c":31.0}}`), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		result, err := svc.GetWeatherByCity(ctx, model.City{Name: "Bom Jesus", State: "PI"})
+		assert.Nil(t, err)
+		if assert.NotNil(t, result) {
+			assert.InDelta(t, 31.0, result.Celsius, 0.01)
+		}
+	})
+
+	t.Run("should query by coordinates when they are known", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Query().Get("q") == "-9.07,-44.36"
+		})).Return(config.NewTestResponse(200,
+			`{"location":{"name":"Bom Jesus","region":"Piaui","country":"Brazil"},"current":{"temp_c":31.0}}`), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{
+			Name: "Bom Jesus", State: "PI", Coordinates: &model.Coordinates{Latitude: -9.07, Longitude: -44.36},
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return 404 when weather api resolves another state", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200,
+			`{"location":{"name":"Bom Jesus","region":"Rio Grande do Sul","country":"Brazil"},"current":{"temp_c":12.0}}`), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "Bom Jesus", State: "PI"})
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+			assert.Equal(t, "can not find weather location for Bom Jesus/PI", err.Error())
+		}
+	})
+
+	t.Run("should return 404 when weather api resolves another country", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200,
+			`{"location":{"name":"Sao Domingos","region":"","country":"Portugal"},"current":{"temp_c":18.0}}`), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+		_, err := svc.GetWeatherByCity(ctx, model.City{Name: "São Domingos", State: "GO"})
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		}
+	})
+}
+
+func TestWeatherService_GetForecastByCity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("should request forecast.json and map days and hours", func(t *testing.T) {
+		body := `{
+			"location":{"name":"Porto Alegre","region":"Rio Grande do Sul","country":"Brazil","lat":-30.03,"lon":-51.2,"localtime":"2025-07-01 14:30"},
+			"forecast":{"forecastday":[{
+				"date":"2025-07-01",
+				"day":{"maxtemp_c":20.1,"mintemp_c":9.8,"avgtemp_c":14.6},
+				"hour":[{"time":"2025-07-01 00:00","temp_c":11.2},{"time":"2025-07-01 01:00","temp_c":10.9}]
+			}]}
+		}`
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Path == "/v1/forecast.json" && req.URL.Query().Get("days") == "2"
+		})).Return(config.NewTestResponse(200, body), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+
+		result, err := svc.GetForecastByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"}, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &model.WeatherForecast{
+			Location: model.Location{
+				Name: "Porto Alegre", Region: "Rio Grande do Sul", Country: "Brazil",
+				Latitude: -30.03, Longitude: -51.2, LocalTime: "2025-07-01 14:30",
+			},
+			Days: []model.WeatherForecastDay{{
+				Date: "2025-07-01", MinCelsius: 9.8, MaxCelsius: 20.1, AvgCelsius: 14.6,
+				Hours: []model.WeatherForecastHour{{Time: "2025-07-01 00:00", Celsius: 11.2}, {Time: "2025-07-01 01:00", Celsius: 10.9}},
+			}},
+		}, result)
+	})
+
+	t.Run("should map weather api errors like current conditions", func(t *testing.T) {
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(400, `{"error":{"code":1006,"message":"No matching location found."}}`), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+
+		_, err := svc.GetForecastByCity(ctx, model.City{Name: "Nowhere", State: "RS"}, 1)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+			assert.Equal(t, model.ErrorCodeLocationNotFound, err.Code)
+		}
+	})
+}
+
+func TestWeatherService_GetHistoryByCity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should request history.json for the date", func(t *testing.T) {
+		body := `{
+			"location":{"name":"Porto Alegre","region":"Rio Grande do Sul","country":"Brazil"},
+			"forecast":{"forecastday":[{"date":"2025-07-03","day":{"maxtemp_c":18.0,"mintemp_c":8.0,"avgtemp_c":12.5}}]}
+		}`
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Path == "/v1/history.json" && req.URL.Query().Get("dt") == "2025-07-03"
+		})).Return(config.NewTestResponse(200, body), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+
+		result, err := svc.GetHistoryByCity(context.Background(), model.City{Name: "Porto Alegre", State: "RS"},
+			time.Date(2025, 7, 3, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, err)
+		if assert.Len(t, result.Days, 1) {
+			assert.Equal(t, model.WeatherForecastDay{Date: "2025-07-03", MinCelsius: 8, MaxCelsius: 18, AvgCelsius: 12.5}, result.Days[0])
+		}
+	})
+}
+
+func TestWeatherService_GetAirQualityByCity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("should request aqi and alerts and map pollutants, indexes and alerts", func(t *testing.T) {
+		body := `{
+			"location":{"name":"Porto Alegre","region":"Rio Grande do Sul","country":"Brazil","lat":-30.03,"lon":-51.2},
+			"current":{"temp_c":14.0,"air_quality":{"co":230.3,"no2":13.2,"o3":40.1,"so2":2.4,"pm2_5":8.5,"pm10":12.1,"us-epa-index":2,"gb-defra-index":4}},
+			"forecast":{"forecastday":[]},
+			"alerts":{"alert":[{
+				"headline":"Chuvas Intensas","msgtype":"Alert","severity":"Moderate","urgency":"Expected","areas":"Rio Grande do Sul",
+				"category":"Met","certainty":"Likely","event":"Chuvas Intensas","note":"","effective":"2025-07-01T10:00:00-03:00",
+				"expires":"2025-07-02T10:00:00-03:00","desc":"Chuva entre 20 e 30 mm/h.","instruction":"Evite áreas alagadas."
+			}]}
+		}`
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+			query := req.URL.Query()
+			return req.URL.Path == "/v1/forecast.json" && query.Get("days") == "1" &&
+				query.Get("aqi") == "yes" && query.Get("alerts") == "yes"
+		})).Return(config.NewTestResponse(200, body), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+
+		result, err := svc.GetAirQualityByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, &model.AirQualityReport{
+			Location: model.Location{Name: "Porto Alegre", Region: "Rio Grande do Sul", Country: "Brazil", Latitude: -30.03, Longitude: -51.2},
+			AirQuality: model.AirQuality{
+				CO: 230.3, NO2: 13.2, O3: 40.1, SO2: 2.4, PM2_5: 8.5, PM10: 12.1,
+				USEPAIndex: 2, USEPACategory: "Moderate", GBDefraIndex: 4, GBDefraBand: "Moderate",
+			},
+			Alerts: []model.WeatherAlert{{
+				Headline: "Chuvas Intensas", Event: "Chuvas Intensas", Category: "Met", Severity: "Moderate", Urgency: "Expected",
+				Areas: "Rio Grande do Sul", Effective: "2025-07-01T10:00:00-03:00", Expires: "2025-07-02T10:00:00-03:00",
+				Description: "Chuva entre 20 e 30 mm/h.", Instruction: "Evite áreas alagadas.",
+			}},
+		}, result)
+	})
+
+	t.Run("should return an empty alert list when there is none", func(t *testing.T) {
+		body := `{
+			"location":{"name":"Porto Alegre","region":"Rio Grande do Sul","country":"Brazil"},
+			"current":{"air_quality":{"pm10":3.0,"us-epa-index":1,"gb-defra-index":1}},
+			"alerts":{"alert":[]}
+		}`
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, body), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+
+		result, err := svc.GetAirQualityByCity(ctx, model.City{Name: "Porto Alegre", State: "RS"})
+
+		assert.Nil(t, err)
+		if assert.NotNil(t, result) {
+			assert.NotNil(t, result.Alerts)
+			assert.Empty(t, result.Alerts)
+			assert.Equal(t, "Good", result.AirQuality.USEPACategory)
+		}
+	})
+}
+
+func TestWeatherProviders_NotImplemented(t *testing.T) {
+	t.Parallel()
+
+	for name, svc := range map[string]gateway.WeatherService{
+		"open meteo":       servicepkg.NewOpenMeteoService(configMock.NewMockHTTPDoer(t), openMeteoSettings),
+		"open weather map": servicepkg.NewOpenWeatherMapService(configMock.NewMockHTTPDoer(t), openWeatherMapSettings),
+	} {
+		t.Run("should return 501 from "+name, func(t *testing.T) {
+			city := model.City{Name: "Porto Alegre", State: "RS"}
+			_, forecastErr := svc.GetForecastByCity(context.Background(), city, 1)
+			_, conditionsErr := svc.GetConditionsByCity(context.Background(), city)
+			_, historyErr := svc.GetHistoryByCity(context.Background(), city, time.Now())
+			_, airQualityErr := svc.GetAirQualityByCity(context.Background(), city)
+
+			for _, err := range []*model.CustomError{forecastErr, conditionsErr, historyErr, airQualityErr} {
+				if assert.NotNil(t, err) {
+					assert.Equal(t, http.StatusNotImplemented, err.StatusCode)
+					assert.Equal(t, model.ErrorCodeNotImplemented, err.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestWeatherService_GetConditionsByCity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should map the extended current conditions", func(t *testing.T) {
+		body := `{
+			"location":{"name":"Porto Alegre","region":"Rio Grande do Sul","country":"Brazil","lat":-30.03,"lon":-51.2,"localtime":"2025-07-01 14:30"},
+			"current":{
+				"last_updated":"2025-07-01 14:15","temp_c":20.0,"feelslike_c":18.2,"humidity":72,
+				"wind_kph":15.1,"wind_degree":140,"wind_dir":"SE","pressure_mb":1016.0,"precip_mm":0.3,"uv":4.0,
+				"condition":{"text":"Partly cloudy","icon":"//cdn.weatherapi.com/116.png"}
+			}
+		}`
+		mockClient := configMock.NewMockHTTPDoer(t)
+		mockClient.On("Do", mock.Anything).Return(config.NewTestResponse(200, body), nil)
+		svc := servicepkg.NewWeatherService(mockClient, weatherSettings)
+
+		result, err := svc.GetConditionsByCity(context.Background(), model.City{Name: "Porto Alegre", State: "RS"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, &model.WeatherConditions{
+			Celsius: 20, FeelsLikeCelsius: 18.2, Humidity: 72,
+			WindKph: 15.1, WindDegree: 140, WindDirection: "SE",
+			PressureMb: 1016, PrecipitationMm: 0.3, UV: 4,
+			ConditionText: "Partly cloudy", ConditionIcon: "//cdn.weatherapi.com/116.png",
+			LastUpdated: "2025-07-01 14:15",
+			Location: model.Location{
+				Name: "Porto Alegre", Region: "Rio Grande do Sul", Country: "Brazil",
+				Latitude: -30.03, Longitude: -51.2, LocalTime: "2025-07-01 14:30",
+			},
+		}, result)
+	})
+}
+
+func TestWeatherService_ParallelLookups(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		latitude, _, _ := strings.Cut(r.URL.Query().Get("q"), ",")
+		fmt.Fprintf(w, `{"location":{"name":"Bom Jesus","region":"Piaui","country":"Brazil"},"current":{"temp_c":%s}}`, latitude)
+	}))
+	t.Cleanup(server.Close)
+	settings := weatherSettings
+	settings.BaseURL = server.URL
+	svc := servicepkg.NewWeatherService(server.Client(), settings)
+
+	t.Run("should answer each concurrent lookup with its own query", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				city := model.City{Name: "Bom Jesus", State: "PI",
+					Coordinates: &model.Coordinates{Latitude: float64(i), Longitude: -44.36}}
+
+				weather, err := svc.GetWeatherByCity(context.Background(), city)
+
+				if assert.Nil(t, err) {
+					assert.InDelta(t, float64(i), weather.Celsius, 0.01)
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
 }
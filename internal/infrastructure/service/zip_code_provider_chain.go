@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/gateway"
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+type ZipCodeProvider = Provider[gateway.ViaCepService]
+
+type ZipCodeProviderChain interface {
+	gateway.ViaCepService
+	Stats() map[string]ProviderStats
+}
+
+type zipCodeProviderChain struct {
+	*providerChain[gateway.ViaCepService]
+}
+
+// NewZipCodeProviderChain tries providers in order. A provider failing with a
+// 5xx (upstream down, timeout, open circuit, answer it can not read) hands the
+// lookup to the next one; a definitive answer such as "can not find zipcode"
+// is returned as is.
+// A miss in the local dataset is not definitive: after an upstream failure the
+// chain returns that failure instead. A nil logger logs nowhere.
+func NewZipCodeProviderChain(logger *slog.Logger, providers ...ZipCodeProvider) ZipCodeProviderChain {
+	return &zipCodeProviderChain{
+		providerChain: newProviderChain("zip code", logger, classifyZipCodeProviderError, providers),
+	}
+}
+
+func (c *zipCodeProviderChain) GetAddressByZipCode(ctx context.Context, zipCode model.ZipCode) (*model.Address, *model.CustomError) {
+	return failOver(ctx, c.providerChain, func(service gateway.ViaCepService) (*model.Address, *model.CustomError) {
+		return service.GetAddressByZipCode(ctx, zipCode)
+	}, "zip_code", string(zipCode))
+}
+
+func classifyZipCodeProviderError(err *model.CustomError) providerOutcome {
+	switch {
+	case err.StatusCode >= http.StatusInternalServerError:
+		return providerFailed
+	case errors.Is(err, errZipCodeNotInDataset):
+		return providerSkipped
+	default:
+		return providerAnswered
+	}
+}
@@ -0,0 +1,166 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	servicepkg "github.com/Berchon/weather-cloud-run/internal/infrastructure/service"
+	serviceMock "github.com/Berchon/weather-cloud-run/internal/infrastructure/service/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipCodeProviderChain_GetAddressByZipCode(t *testing.T) {
+	ctx := context.Background()
+	zip := model.ZipCode("90040000")
+
+	t.Run("should return first provider answer", func(t *testing.T) {
+		address := &model.Address{City: "Porto Alegre"}
+		first := serviceMock.NewMockViaCepService(t)
+		first.On("GetAddressByZipCode", mock.Anything, zip).Return(address, nil).Once()
+		second := serviceMock.NewMockViaCepService(t)
+
+		chain := servicepkg.NewZipCodeProviderChain(nil,
+			servicepkg.ZipCodeProvider{Name: "viacep", Service: first},
+			servicepkg.ZipCodeProvider{Name: "brasilapi", Service: second},
+		)
+		result, err := chain.GetAddressByZipCode(ctx, zip)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Porto Alegre", result.City)
+		assert.Equal(t, servicepkg.ProviderStats{Answered: 1}, chain.Stats()["viacep"])
+		assert.Equal(t, servicepkg.ProviderStats{}, chain.Stats()["brasilapi"])
+	})
+
+	t.Run("should fall through to next provider on 5xx errors", func(t *testing.T) {
+		address := &model.Address{City: "Porto Alegre"}
+		first := serviceMock.NewMockViaCepService(t)
+		first.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCustomError(http.StatusServiceUnavailable, "via cep is temporarily unavailable")).Once()
+		second := serviceMock.NewMockViaCepService(t)
+		second.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCustomError(http.StatusBadGateway, "error unmarshalling response from brasil api")).Once()
+		third := serviceMock.NewMockViaCepService(t)
+		third.On("GetAddressByZipCode", mock.Anything, zip).Return(address, nil).Once()
+
+		chain := servicepkg.NewZipCodeProviderChain(nil,
+			servicepkg.ZipCodeProvider{Name: "viacep", Service: first},
+			servicepkg.ZipCodeProvider{Name: "brasilapi", Service: second},
+			servicepkg.ZipCodeProvider{Name: "local", Service: third},
+		)
+		result, err := chain.GetAddressByZipCode(ctx, zip)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Porto Alegre", result.City)
+		assert.Equal(t, servicepkg.ProviderStats{Failed: 1}, chain.Stats()["viacep"])
+		assert.Equal(t, servicepkg.ProviderStats{Failed: 1}, chain.Stats()["brasilapi"])
+		assert.Equal(t, servicepkg.ProviderStats{Answered: 1}, chain.Stats()["local"])
+	})
+
+	t.Run("should not fall through on not found", func(t *testing.T) {
+		first := serviceMock.NewMockViaCepService(t)
+		first.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCustomError(http.StatusNotFound, "can not find zipcode")).Once()
+		second := serviceMock.NewMockViaCepService(t)
+
+		chain := servicepkg.NewZipCodeProviderChain(nil,
+			servicepkg.ZipCodeProvider{Name: "viacep", Service: first},
+			servicepkg.ZipCodeProvider{Name: "brasilapi", Service: second},
+		)
+		_, err := chain.GetAddressByZipCode(ctx, zip)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusNotFound, err.StatusCode)
+		}
+	})
+
+	t.Run("should return last error when every provider fails", func(t *testing.T) {
+		first := serviceMock.NewMockViaCepService(t)
+		first.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCustomError(http.StatusInternalServerError, "first failure")).Once()
+		second := serviceMock.NewMockViaCepService(t)
+		second.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCustomError(http.StatusServiceUnavailable, "second failure")).Once()
+
+		chain := servicepkg.NewZipCodeProviderChain(nil,
+			servicepkg.ZipCodeProvider{Name: "viacep", Service: first},
+			servicepkg.ZipCodeProvider{Name: "brasilapi", Service: second},
+		)
+		_, err := chain.GetAddressByZipCode(ctx, zip)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+			assert.Equal(t, "second failure", err.Error())
+		}
+	})
+
+	t.Run("should stop trying providers when context is done", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		first := serviceMock.NewMockViaCepService(t)
+		first.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCustomError(http.StatusInternalServerError, "context canceled")).Once()
+		second := serviceMock.NewMockViaCepService(t)
+
+		chain := servicepkg.NewZipCodeProviderChain(nil,
+			servicepkg.ZipCodeProvider{Name: "viacep", Service: first},
+			servicepkg.ZipCodeProvider{Name: "brasilapi", Service: second},
+		)
+		_, err := chain.GetAddressByZipCode(cancelledCtx, zip)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("should not let a local dataset miss hide an upstream failure", func(t *testing.T) {
+		local, err := servicepkg.NewLocalZipCodeService(writeDataset(t, `[{"cep":"01001000","localidade":"São Paulo","uf":"SP"}]`))
+		require.NoError(t, err)
+		first := serviceMock.NewMockViaCepService(t)
+		first.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCustomError(http.StatusServiceUnavailable, "via cep is temporarily unavailable")).Twice()
+		second := serviceMock.NewMockViaCepService(t)
+		second.On("GetAddressByZipCode", mock.Anything, zip).
+			Return(nil, model.NewCustomError(http.StatusGatewayTimeout, "brasil api timed out")).Twice()
+
+		chain := servicepkg.NewZipCodeProviderChain(nil,
+			servicepkg.ZipCodeProvider{Name: "viacep", Service: first},
+			servicepkg.ZipCodeProvider{Name: "brasilapi", Service: second},
+			servicepkg.ZipCodeProvider{Name: "local", Service: local},
+		)
+		cached := servicepkg.NewCachedViaCepService(chain, 10, time.Hour, time.Hour)
+
+		for range 2 {
+			_, lookupErr := cached.GetAddressByZipCode(ctx, zip)
+			if assert.NotNil(t, lookupErr) {
+				assert.Equal(t, http.StatusGatewayTimeout, lookupErr.StatusCode)
+			}
+		}
+		assert.Equal(t, 0, cached.Stats().Size)
+		assert.Equal(t, servicepkg.ProviderStats{}, chain.Stats()["local"])
+	})
+
+	t.Run("should return a local dataset miss when no provider failed", func(t *testing.T) {
+		local, err := servicepkg.NewLocalZipCodeService(writeDataset(t, `[]`))
+		require.NoError(t, err)
+
+		chain := servicepkg.NewZipCodeProviderChain(nil, servicepkg.ZipCodeProvider{Name: "local", Service: local})
+		_, lookupErr := chain.GetAddressByZipCode(ctx, zip)
+
+		if assert.NotNil(t, lookupErr) {
+			assert.Equal(t, http.StatusNotFound, lookupErr.StatusCode)
+			assert.Equal(t, model.ErrorCodeZipCodeNotFound, lookupErr.Code)
+		}
+	})
+
+	t.Run("should return error when no provider is configured", func(t *testing.T) {
+		chain := servicepkg.NewZipCodeProviderChain(nil)
+		_, err := chain.GetAddressByZipCode(ctx, zip)
+
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+		}
+	})
+}
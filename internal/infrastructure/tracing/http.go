@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Propagator reads and writes the W3C traceparent and tracestate headers.
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// Middleware continues the trace of an inbound traceparent header, or starts a
+// new one, with a server span named after the chi route pattern.
+func Middleware(provider trace.TracerProvider) func(http.Handler) http.Handler {
+	tracer := provider.Tracer(InstrumentationName)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := Propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method, trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+				))
+			defer span.End()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+				span.SetName(r.Method + " " + routeContext.RoutePattern())
+				span.SetAttributes(attribute.String("http.route", routeContext.RoutePattern()))
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, fmt.Sprintf("status %d", status))
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/tracing"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware(t *testing.T) {
+	provider, exporter := tracing.NewInMemoryTracerProvider()
+	router := chi.NewRouter()
+	router.Use(tracing.Middleware(provider))
+	var handlerSpan trace.SpanContext
+	router.Get("/temperature/{zipCode}", func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	t.Run("should continue the inbound trace in a span named after the route", func(t *testing.T) {
+		exporter.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		span := spans[0]
+		assert.Equal(t, "GET /temperature/{zipCode}", span.Name)
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+		assert.True(t, span.Parent.IsRemote())
+		assert.Equal(t, span.SpanContext, handlerSpan)
+		assert.Contains(t, span.Attributes, attribute.String("http.route", "/temperature/{zipCode}"))
+		assert.Contains(t, span.Attributes, attribute.Int("http.response.status_code", http.StatusBadGateway))
+		assert.Equal(t, codes.Error, span.Status.Code)
+	})
+
+	t.Run("should start a new trace without traceparent", func(t *testing.T) {
+		exporter.Reset()
+
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/temperature/12345678", nil))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.True(t, spans[0].SpanContext.TraceID().IsValid())
+		assert.False(t, spans[0].Parent.IsValid())
+	})
+}
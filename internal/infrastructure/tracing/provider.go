@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// InstrumentationName is the tracer name every span of the service is
+// created under.
+const InstrumentationName = "github.com/Berchon/weather-cloud-run"
+
+type Settings struct {
+	Exporter     string
+	OTLPEndpoint string
+	ServiceName  string
+}
+
+// NewTracerProvider builds a provider exporting in batches to the configured
+// exporter. With ExporterNone spans are still created, so the trace context
+// reaches upstreams, but they are not exported.
+func NewTracerProvider(ctx context.Context, settings Settings) (*sdktrace.TracerProvider, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", settings.ServiceName))),
+	}
+
+	switch settings.Exporter {
+	case ExporterNone, "":
+	case ExporterStdout:
+		exporter, err := stdouttrace.New()
+		if err != nil {
+			return nil, fmt.Errorf("creating stdout exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		exporterOptions := []otlptracehttp.Option{}
+		if settings.OTLPEndpoint != "" {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithEndpoint(settings.OTLPEndpoint), otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, exporterOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("creating otlp exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", settings.Exporter)
+	}
+
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// NewInMemoryTracerProvider exports synchronously to memory, so tests can
+// assert on the spans as soon as the traced call returns.
+func NewInMemoryTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
@@ -0,0 +1,30 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	for _, exporter := range []string{tracing.ExporterNone, "", tracing.ExporterStdout, tracing.ExporterOTLP} {
+		t.Run("Should build a provider When exporter is "+exporter, func(t *testing.T) {
+			provider, err := tracing.NewTracerProvider(context.Background(), tracing.Settings{
+				Exporter:     exporter,
+				OTLPEndpoint: "127.0.0.1:4318",
+				ServiceName:  "weather-test",
+			})
+			require.NoError(t, err)
+			assert.NoError(t, provider.Shutdown(context.Background()))
+		})
+	}
+
+	t.Run("Should return an error When exporter is unknown", func(t *testing.T) {
+		provider, err := tracing.NewTracerProvider(context.Background(), tracing.Settings{Exporter: "jaeger"})
+		assert.Nil(t, provider)
+		assert.EqualError(t, err, `unknown tracing exporter "jaeger"`)
+	})
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+type GetAirQualityByZipCodeHandler interface {
+	HttpHandler
+}
+
+type getAirQualityByZipCodeHandler struct {
+	usecase  usecase.GetAirQualityByZipCodeUsecase
+	response *responseHandler
+}
+
+func NewGetAirQualityByZipCodeHandler(usecase usecase.GetAirQualityByZipCodeUsecase) GetAirQualityByZipCodeHandler {
+	response := NewResponseHandler()
+	return &getAirQualityByZipCodeHandler{
+		usecase:  usecase,
+		response: response,
+	}
+}
+
+func (h *getAirQualityByZipCodeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	zipCode, err := validate.ZipCode(r)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	output, err := h.usecase.GetAirQualityByZipCode(r.Context(), *zipCode)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	h.response.RequestResponse(w, r, output, http.StatusOK)
+}
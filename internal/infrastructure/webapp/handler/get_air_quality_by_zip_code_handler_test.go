@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAirQualityByZipCodeHandler_HTTP(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetAirQualityByZipCodeUsecase(t)
+	r := chi.NewRouter()
+	r.Get("/air-quality/{zipCode}", handler.NewGetAirQualityByZipCodeHandler(mockUsecase).Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	t.Run("should return 422 when ZipCode validation fails", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/air-quality/12")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.JSONEq(t, `{"status_code":422,"message":"invalid zipcode"}`, body)
+	})
+
+	t.Run("should return 501 when no provider has air quality", func(t *testing.T) {
+		mockUsecase.On("GetAirQualityByZipCode", mock.Anything, model.ZipCode("12345678")).
+			Return(nil, model.NewCustomError(http.StatusNotImplemented, "open meteo does not support air quality")).
+			Once()
+
+		status, body := getResponse(t, server.URL+"/air-quality/12345678")
+		assert.Equal(t, http.StatusNotImplemented, status)
+		assert.JSONEq(t, `{"status_code":501,"message":"open meteo does not support air quality"}`, body)
+	})
+
+	t.Run("should return 200 with the air quality and alerts", func(t *testing.T) {
+		report := model.AirQualityReport{
+			Location: model.Location{Name: "Porto Alegre"},
+			AirQuality: model.AirQuality{
+				CO: 230.3, NO2: 13.2, O3: 40.1, SO2: 2.4, PM2_5: 8.5, PM10: 12.1,
+				USEPAIndex: 1, USEPACategory: "Good", GBDefraIndex: 1, GBDefraBand: "Low",
+			},
+			Alerts: []model.WeatherAlert{{
+				Headline: "Chuvas Intensas", Event: "Chuvas Intensas", Severity: "Moderate", Urgency: "Expected",
+				Areas: "Rio Grande do Sul", Effective: "2025-07-01T10:00:00-03:00", Expires: "2025-07-02T10:00:00-03:00",
+				Description: "Chuva entre 20 e 30 mm/h.", Instruction: "Evite áreas alagadas.",
+			}},
+		}
+		mockUsecase.On("GetAirQualityByZipCode", mock.Anything, model.ZipCode("12345678")).Return(&report, nil).Once()
+
+		status, body := getResponse(t, server.URL+"/air-quality/12345678")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{
+			"location":{"name":"Porto Alegre","lat":0,"lon":0},
+			"air_quality":{"co":230.3,"no2":13.2,"o3":40.1,"so2":2.4,"pm2_5":8.5,"pm10":12.1,
+				"us_epa_index":1,"us_epa_category":"Good","gb_defra_index":1,"gb_defra_band":"Low"},
+			"alerts":[{"headline":"Chuvas Intensas","event":"Chuvas Intensas","category":"","severity":"Moderate",
+				"urgency":"Expected","areas":"Rio Grande do Sul","effective":"2025-07-01T10:00:00-03:00",
+				"expires":"2025-07-02T10:00:00-03:00","description":"Chuva entre 20 e 30 mm/h.","instruction":"Evite áreas alagadas."}]
+		}`, body)
+	})
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+type GetConditionsByZipCodeHandler interface {
+	HttpHandler
+}
+
+type getConditionsByZipCodeHandler struct {
+	usecase  usecase.GetConditionsByZipCodeUsecase
+	response *responseHandler
+}
+
+func NewGetConditionsByZipCodeHandler(usecase usecase.GetConditionsByZipCodeUsecase) GetConditionsByZipCodeHandler {
+	response := NewResponseHandler()
+	return &getConditionsByZipCodeHandler{
+		usecase:  usecase,
+		response: response,
+	}
+}
+
+func (h *getConditionsByZipCodeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	zipCode, err := validate.ZipCode(r)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	output, err := h.usecase.GetConditionsByZipCode(r.Context(), *zipCode)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	h.response.RequestResponse(w, r, output, http.StatusOK)
+}
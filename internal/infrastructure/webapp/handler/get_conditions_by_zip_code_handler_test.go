@@ -0,0 +1,69 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetConditionsByZipCodeHandler_HTTP(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetConditionsByZipCodeUsecase(t)
+	r := chi.NewRouter()
+	r.Get("/conditions/{zipCode}", handler.NewGetConditionsByZipCodeHandler(mockUsecase).Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	t.Run("should return 422 when ZipCode validation fails", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/conditions/12")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.JSONEq(t, `{"status_code":422,"message":"invalid zipcode"}`, body)
+	})
+
+	t.Run("should return 501 when no provider has extended conditions", func(t *testing.T) {
+		mockUsecase.On("GetConditionsByZipCode", mock.Anything, model.ZipCode("12345678")).
+			Return(nil, model.NewCustomError(http.StatusNotImplemented, "open meteo does not support extended conditions")).
+			Once()
+
+		status, body := getResponse(t, server.URL+"/conditions/12345678")
+		assert.Equal(t, http.StatusNotImplemented, status)
+		assert.JSONEq(t, `{"status_code":501,"message":"open meteo does not support extended conditions"}`, body)
+	})
+
+	t.Run("should return 200 with the conditions", func(t *testing.T) {
+		conditions := model.Conditions{
+			Temperature:   model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293},
+			FeelsLike:     model.Temperature{Celsius: 18, Fahrenheit: 64.4, Kelvin: 291},
+			Humidity:      72,
+			Wind:          model.Wind{Kph: 15.1, Mph: 9.4, Degree: 140, Direction: "SE"},
+			Pressure:      model.Pressure{Mb: 1016, InHg: 30},
+			Precipitation: model.Precipitation{Mm: 0.3, In: 0.01},
+			UV:            4,
+			Condition:     model.Condition{Text: "Partly cloudy", Icon: "//cdn.weatherapi.com/116.png"},
+			LastUpdated:   "2025-07-01 14:15",
+			Location:      model.Location{Name: "Porto Alegre"},
+		}
+		mockUsecase.On("GetConditionsByZipCode", mock.Anything, model.ZipCode("12345678")).Return(&conditions, nil).Once()
+
+		status, body := getResponse(t, server.URL+"/conditions/12345678")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{
+			"temp_C":20,"temp_F":68,"temp_K":293,
+			"feels_like":{"temp_C":18,"temp_F":64.4,"temp_K":291},
+			"humidity":72,
+			"wind":{"kph":15.1,"mph":9.4,"degree":140,"direction":"SE"},
+			"pressure":{"mb":1016,"in_hg":30},
+			"precipitation":{"mm":0.3,"in":0.01},
+			"uv":4,
+			"condition":{"text":"Partly cloudy","icon":"//cdn.weatherapi.com/116.png"},
+			"last_updated":"2025-07-01 14:15",
+			"location":{"name":"Porto Alegre","lat":0,"lon":0}
+		}`, body)
+	})
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+type GetForecastByZipCodeHandler interface {
+	HttpHandler
+}
+
+type getForecastByZipCodeHandler struct {
+	usecase  usecase.GetForecastByZipCodeUsecase
+	maxDays  int
+	response *responseHandler
+}
+
+func NewGetForecastByZipCodeHandler(usecase usecase.GetForecastByZipCodeUsecase, maxDays int) GetForecastByZipCodeHandler {
+	response := NewResponseHandler()
+	return &getForecastByZipCodeHandler{
+		usecase:  usecase,
+		maxDays:  maxDays,
+		response: response,
+	}
+}
+
+func (h *getForecastByZipCodeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	zipCode, err := validate.ZipCode(r)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	days, err := validate.ForecastDays(r, h.maxDays)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	output, err := h.usecase.GetForecastByZipCode(r.Context(), *zipCode, days)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	h.response.RequestResponse(w, r, output, http.StatusOK)
+}
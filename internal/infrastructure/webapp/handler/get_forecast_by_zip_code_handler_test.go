@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetForecastByZipCodeHandler_HTTP(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetForecastByZipCodeUsecase(t)
+	r := chi.NewRouter()
+	r.Get("/forecast/{zipCode}", handler.NewGetForecastByZipCodeHandler(mockUsecase, 3).Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	t.Run("should return 422 when ZipCode validation fails", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/forecast/12")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.JSONEq(t, `{"status_code":422,"message":"invalid zipcode"}`, body)
+	})
+
+	t.Run("should return 400 when days is out of bounds", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/forecast/12345678?days=10")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.JSONEq(t, `{"status_code":400,"message":"days must be a number between 1 and 3"}`, body)
+	})
+
+	t.Run("should return 200 with the forecast", func(t *testing.T) {
+		forecast := model.Forecast{
+			Location: model.Location{Name: "Porto Alegre"},
+			Days: []model.ForecastDay{{
+				Date:  "2025-07-01",
+				Min:   model.Temperature{Celsius: 10, Fahrenheit: 50, Kelvin: 283},
+				Max:   model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293},
+				Avg:   model.Temperature{Celsius: 15, Fahrenheit: 59, Kelvin: 288},
+				Hours: []model.ForecastHour{{Time: "2025-07-01 00:00", Temperature: model.Temperature{Celsius: 11, Fahrenheit: 51.8, Kelvin: 284}}},
+			}},
+		}
+		mockUsecase.On("GetForecastByZipCode", mock.Anything, model.ZipCode("12345678"), 2).Return(&forecast, nil).Once()
+
+		status, body := getResponse(t, server.URL+"/forecast/12345678?days=2")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{
+			"location":{"name":"Porto Alegre","lat":0,"lon":0},
+			"days":[{
+				"date":"2025-07-01",
+				"min":{"temp_C":10,"temp_F":50,"temp_K":283},
+				"max":{"temp_C":20,"temp_F":68,"temp_K":293},
+				"avg":{"temp_C":15,"temp_F":59,"temp_K":288},
+				"hours":[{"time":"2025-07-01 00:00","temp_C":11,"temp_F":51.8,"temp_K":284}]
+			}]
+		}`, body)
+	})
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+type GetHistoryByZipCodeHandler interface {
+	HttpHandler
+}
+
+type getHistoryByZipCodeHandler struct {
+	usecase  usecase.GetHistoryByZipCodeUsecase
+	maxDays  int
+	response *responseHandler
+}
+
+func NewGetHistoryByZipCodeHandler(usecase usecase.GetHistoryByZipCodeUsecase, maxDays int) GetHistoryByZipCodeHandler {
+	response := NewResponseHandler()
+	return &getHistoryByZipCodeHandler{
+		usecase:  usecase,
+		maxDays:  maxDays,
+		response: response,
+	}
+}
+
+func (h *getHistoryByZipCodeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	zipCode, err := validate.ZipCode(r)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	date, err := validate.HistoryDate(r, h.maxDays)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	output, err := h.usecase.GetHistoryByZipCode(r.Context(), *zipCode, *date)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	h.response.RequestResponse(w, r, output, http.StatusOK)
+}
@@ -0,0 +1,62 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetHistoryByZipCodeHandler_HTTP(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetHistoryByZipCodeUsecase(t)
+	r := chi.NewRouter()
+	r.Get("/temperature/{zipCode}/history", handler.NewGetHistoryByZipCodeHandler(mockUsecase, 7).Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	t.Run("should return 422 when the date is too old", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/temperature/12345678/history?date=2010-01-01")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.JSONEq(t, `{"status_code":422,"message":"date 2010-01-01 is more than 7 days ago"}`, body)
+	})
+
+	t.Run("should return 422 when the date is malformed", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/temperature/12345678/history?date=yesterday")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.JSONEq(t, `{"status_code":422,"message":"invalid date \"yesterday\", expected YYYY-MM-DD"}`, body)
+	})
+
+	t.Run("should return 200 with the history of the day", func(t *testing.T) {
+		yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+		history := model.History{
+			Location: model.Location{Name: "Porto Alegre"},
+			ForecastDay: model.ForecastDay{
+				Date: yesterday,
+				Min:  model.Temperature{Celsius: 10, Fahrenheit: 50, Kelvin: 283},
+				Max:  model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293},
+				Avg:  model.Temperature{Celsius: 15, Fahrenheit: 59, Kelvin: 288},
+			},
+		}
+		mockUsecase.On("GetHistoryByZipCode", mock.Anything, model.ZipCode("12345678"), mock.MatchedBy(func(date time.Time) bool {
+			return date.Format("2006-01-02") == yesterday
+		})).Return(&history, nil).Once()
+
+		status, body := getResponse(t, server.URL+"/temperature/12345678/history?date="+yesterday)
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{
+			"location":{"name":"Porto Alegre","lat":0,"lon":0},
+			"date":"`+yesterday+`",
+			"min":{"temp_C":10,"temp_F":50,"temp_K":283},
+			"max":{"temp_C":20,"temp_F":68,"temp_K":293},
+			"avg":{"temp_C":15,"temp_F":59,"temp_K":288},
+			"hours":null
+		}`, body)
+	})
+}
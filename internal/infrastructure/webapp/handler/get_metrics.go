@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type GetMetricsHandler interface {
+	HttpHandler
+}
+
+// MetricsWriter renders metrics in the Prometheus text exposition format.
+type MetricsWriter interface {
+	WriteText(w io.Writer) error
+}
+
+type getMetricsHandler struct {
+	metrics MetricsWriter
+	logger  *slog.Logger
+}
+
+// NewGetMetricsHandler logs write failures to logger, or nowhere when it is
+// nil.
+func NewGetMetricsHandler(metrics MetricsWriter, logger *slog.Logger) GetMetricsHandler {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	return &getMetricsHandler{
+		metrics: metrics,
+		logger:  logger,
+	}
+}
+
+func (h *getMetricsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metricsContentType)
+	w.WriteHeader(http.StatusOK)
+	if err := h.metrics.WriteText(w); err != nil {
+		h.logger.ErrorContext(r.Context(), "writing metrics failed", "error", err)
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMetricsHandler_Handle(t *testing.T) {
+	t.Run("should render the registry in the prometheus text format", func(t *testing.T) {
+		registry := metrics.NewRegistry()
+		registry.NewCounterVec("things_total", "Things.", "kind").Inc("a")
+		handler := NewGetMetricsHandler(registry, nil)
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		w := httptest.NewRecorder()
+
+		handler.Handle(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "# HELP things_total Things.\n# TYPE things_total counter\nthings_total{kind=\"a\"} 1\n", w.Body.String())
+	})
+
+	t.Run("should log to the injected logger when writing fails", func(t *testing.T) {
+		var out bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&out, nil))
+		handler := NewGetMetricsHandler(failingMetricsWriter{}, logger)
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		w := httptest.NewRecorder()
+
+		handler.Handle(w, req)
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+		assert.Equal(t, "ERROR", entry["level"])
+		assert.Equal(t, "writing metrics failed", entry["msg"])
+		assert.Equal(t, "connection reset", entry["error"])
+	})
+}
+
+type failingMetricsWriter struct{}
+
+func (failingMetricsWriter) WriteText(io.Writer) error {
+	return errors.New("connection reset")
+}
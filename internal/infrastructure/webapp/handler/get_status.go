@@ -8,22 +8,43 @@ type GetStatusHandler interface {
 	HttpHandler
 }
 
+// StatusReporter exposes runtime details of a component (cache counters,
+// circuit breaker state, ...) in the /status response.
+type StatusReporter interface {
+	Report() interface{}
+}
+
+type StatusReporterFunc func() interface{}
+
+func (f StatusReporterFunc) Report() interface{} {
+	return f()
+}
+
 type getStatusHandler struct {
-	response *responseHandler
+	reporters map[string]StatusReporter
+	response  *responseHandler
 }
 
 type status struct {
-	Status string `json:"status"`
+	Status     string                 `json:"status"`
+	Components map[string]interface{} `json:"components,omitempty"`
 }
 
-func NewGetStatusHandler() GetStatusHandler {
+func NewGetStatusHandler(reporters map[string]StatusReporter) GetStatusHandler {
 	response := NewResponseHandler()
 	return &getStatusHandler{
-		response: response,
+		reporters: reporters,
+		response:  response,
 	}
 }
 
 func (getStatusHandler *getStatusHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	status := status{Status: "Healthy"}
+	if len(getStatusHandler.reporters) > 0 {
+		status.Components = make(map[string]interface{}, len(getStatusHandler.reporters))
+		for name, reporter := range getStatusHandler.reporters {
+			status.Components[name] = reporter.Report()
+		}
+	}
 	getStatusHandler.response.RequestResponse(w, r, status, http.StatusOK)
 }
@@ -9,12 +9,29 @@ import (
 )
 
 func TestGetStatusHandler_Handle(t *testing.T) {
-	handler := NewGetStatusHandler()
-	req := httptest.NewRequest(http.MethodGet, "/status", nil)
-	w := httptest.NewRecorder()
+	t.Run("should return healthy status", func(t *testing.T) {
+		handler := NewGetStatusHandler(nil)
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		w := httptest.NewRecorder()
 
-	handler.Handle(w, req)
+		handler.Handle(w, req)
 
-	assert.Equal(t, http.StatusOK, w.Code)
-	assert.JSONEq(t, `{"status":"Healthy"}`, w.Body.String())
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status":"Healthy"}`, w.Body.String())
+	})
+
+	t.Run("should include components reports", func(t *testing.T) {
+		handler := NewGetStatusHandler(map[string]StatusReporter{
+			"cache": StatusReporterFunc(func() interface{} {
+				return map[string]int{"hits": 3}
+			}),
+		})
+		req := httptest.NewRequest(http.MethodGet, "/status", nil)
+		w := httptest.NewRecorder()
+
+		handler.Handle(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status":"Healthy","components":{"cache":{"hits":3}}}`, w.Body.String())
+	})
 }
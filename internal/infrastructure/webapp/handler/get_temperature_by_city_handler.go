@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+type GetTemperatureByCityHandler interface {
+	HttpHandler
+}
+
+type getTemperatureByCityHandler struct {
+	usecase  usecase.GetTemperatureByLocationUsecase
+	response *responseHandler
+}
+
+func NewGetTemperatureByCityHandler(usecase usecase.GetTemperatureByLocationUsecase) GetTemperatureByCityHandler {
+	response := NewResponseHandler()
+	return &getTemperatureByCityHandler{
+		usecase:  usecase,
+		response: response,
+	}
+}
+
+func (h *getTemperatureByCityHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	city, err := validate.City(r)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	output, err := h.usecase.GetTemperatureByCity(r.Context(), *city)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	h.response.RequestResponse(w, r, output, http.StatusOK)
+}
@@ -0,0 +1,48 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTemperatureByCityHandler_HTTP(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetTemperatureByLocationUsecase(t)
+	r := chi.NewRouter()
+	r.Get("/temperature/city/{uf}/{city}", handler.NewGetTemperatureByCityHandler(mockUsecase).Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	t.Run("should return 422 when the UF is unknown", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/temperature/city/XX/Porto%20Alegre")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.JSONEq(t, `{"status_code":422,"message":"invalid city"}`, body)
+	})
+
+	t.Run("should return 200 with the temperature of the city", func(t *testing.T) {
+		mockUsecase.On("GetTemperatureByCity", mock.Anything, model.City{Name: "São Paulo", State: "SP"}).
+			Return(&model.Temperature{Celsius: 22, Fahrenheit: 71.6, Kelvin: 295}, nil).
+			Once()
+
+		status, body := getResponse(t, server.URL+"/temperature/city/sp/S%C3%A3o%20Paulo")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"temp_C":22,"temp_F":71.6,"temp_K":295}`, body)
+	})
+
+	t.Run("should return the usecase error", func(t *testing.T) {
+		mockUsecase.On("GetTemperatureByCity", mock.Anything, model.City{Name: "Nowhere", State: "RS"}).
+			Return(nil, model.NewCodedError(model.ErrorCodeLocationNotFound, http.StatusNotFound, "can not find weather location for Nowhere/RS")).
+			Once()
+
+		status, body := getResponse(t, server.URL+"/temperature/city/RS/Nowhere")
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.JSONEq(t, `{"status_code":404,"message":"can not find weather location for Nowhere/RS"}`, body)
+	})
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+type GetTemperatureByCoordinatesHandler interface {
+	HttpHandler
+}
+
+type getTemperatureByCoordinatesHandler struct {
+	usecase  usecase.GetTemperatureByLocationUsecase
+	response *responseHandler
+}
+
+func NewGetTemperatureByCoordinatesHandler(usecase usecase.GetTemperatureByLocationUsecase) GetTemperatureByCoordinatesHandler {
+	response := NewResponseHandler()
+	return &getTemperatureByCoordinatesHandler{
+		usecase:  usecase,
+		response: response,
+	}
+}
+
+func (h *getTemperatureByCoordinatesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	coordinates, err := validate.Coordinates(r)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	output, err := h.usecase.GetTemperatureByCoordinates(r.Context(), *coordinates)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	h.response.RequestResponse(w, r, output, http.StatusOK)
+}
@@ -0,0 +1,44 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTemperatureByCoordinatesHandler_HTTP(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetTemperatureByLocationUsecase(t)
+	r := chi.NewRouter()
+	r.Get("/temperature/coordinates", handler.NewGetTemperatureByCoordinatesHandler(mockUsecase).Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	t.Run("should return 400 when lon is missing", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/temperature/coordinates?lat=-30.03")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.JSONEq(t, `{"status_code":400,"message":"lat and lon query params are required"}`, body)
+	})
+
+	t.Run("should return 422 when lat is out of range", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/temperature/coordinates?lat=-130&lon=-51.2")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.JSONEq(t, `{"status_code":422,"message":"invalid coordinates"}`, body)
+	})
+
+	t.Run("should return 200 with the temperature at the coordinates", func(t *testing.T) {
+		mockUsecase.On("GetTemperatureByCoordinates", mock.Anything, model.Coordinates{Latitude: -30.03, Longitude: -51.2}).
+			Return(&model.Temperature{Celsius: 12, Fahrenheit: 53.6, Kelvin: 285}, nil).
+			Once()
+
+		status, body := getResponse(t, server.URL+"/temperature/coordinates?lat=-30.03&lon=-51.2")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"temp_C":12,"temp_F":53.6,"temp_K":285}`, body)
+	})
+}
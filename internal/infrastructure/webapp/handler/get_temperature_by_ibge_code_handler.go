@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+type GetTemperatureByIBGECodeHandler interface {
+	HttpHandler
+}
+
+type getTemperatureByIBGECodeHandler struct {
+	usecase  usecase.GetTemperatureByLocationUsecase
+	response *responseHandler
+}
+
+func NewGetTemperatureByIBGECodeHandler(usecase usecase.GetTemperatureByLocationUsecase) GetTemperatureByIBGECodeHandler {
+	response := NewResponseHandler()
+	return &getTemperatureByIBGECodeHandler{
+		usecase:  usecase,
+		response: response,
+	}
+}
+
+func (h *getTemperatureByIBGECodeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ibgeCode, err := validate.IBGECode(r)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	output, err := h.usecase.GetTemperatureByIBGECode(r.Context(), *ibgeCode)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	h.response.RequestResponse(w, r, output, http.StatusOK)
+}
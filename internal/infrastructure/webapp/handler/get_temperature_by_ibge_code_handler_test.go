@@ -0,0 +1,38 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTemperatureByIBGECodeHandler_HTTP(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetTemperatureByLocationUsecase(t)
+	r := chi.NewRouter()
+	r.Get("/temperature/ibge/{ibgeCode}", handler.NewGetTemperatureByIBGECodeHandler(mockUsecase).Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	t.Run("should return 422 when the ibge code is invalid", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/temperature/ibge/123")
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.JSONEq(t, `{"status_code":422,"message":"invalid ibge code"}`, body)
+	})
+
+	t.Run("should return 200 with the temperature of the municipality", func(t *testing.T) {
+		mockUsecase.On("GetTemperatureByIBGECode", mock.Anything, model.IBGECode("4314902")).
+			Return(&model.Temperature{Celsius: 12, Fahrenheit: 53.6, Kelvin: 285}, nil).
+			Once()
+
+		status, body := getResponse(t, server.URL+"/temperature/ibge/4314902")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"temp_C":12,"temp_F":53.6,"temp_K":285}`, body)
+	})
+}
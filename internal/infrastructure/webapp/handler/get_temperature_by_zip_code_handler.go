@@ -31,6 +31,22 @@ func (h *getTemperatureByZipCodeHandler) Handle(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	includes, err := validate.TemperatureIncludes(r)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	if includes.Any() {
+		output, err := h.usecase.GetDetailedTemperatureByZipCode(r.Context(), *zipCode, includes)
+		if err != nil {
+			h.response.RequestResponse(w, r, err, err.StatusCode)
+			return
+		}
+		h.response.RequestResponse(w, r, output, http.StatusOK)
+		return
+	}
+
 	output, err := h.usecase.GetTemperatureByZipCode(r.Context(), *zipCode)
 	if err != nil {
 		h.response.RequestResponse(w, r, err, err.StatusCode)
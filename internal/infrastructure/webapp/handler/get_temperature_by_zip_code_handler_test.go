@@ -1,6 +1,7 @@
 package handler_test
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -8,10 +9,12 @@ import (
 
 	"github.com/Berchon/weather-cloud-run/internal/business/model"
 	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/requestid"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func setupTestServer(t *testing.T, mockUsecase *usecaseMock.MockGetTemperatureByZipCodeUsecase) *httptest.Server {
@@ -54,11 +57,7 @@ func TestGetTemperatureByZipCodeHandler_HTTP(t *testing.T) {
 
 	t.Run("should return 200 when usecase succeeds", func(t *testing.T) {
 		zipCode := "12345678"
-		result := map[string]float64{
-			"temp_C": 21.2,
-			"temp_F": 70.2,
-			"temp_K": 294.2,
-		}
+		result := model.Temperature{Celsius: 21.2, Fahrenheit: 70.2, Kelvin: 294.2}
 		mockUsecase.On("GetTemperatureByZipCode", mock.Anything, model.ZipCode(zipCode)).
 			Return(&result, nil).
 			Once()
@@ -67,4 +66,132 @@ func TestGetTemperatureByZipCodeHandler_HTTP(t *testing.T) {
 		assert.Equal(t, http.StatusOK, status)
 		assert.JSONEq(t, `{"temp_C":21.2,"temp_F":70.2,"temp_K":294.2}`, body)
 	})
+
+	t.Run("should return 400 when include value is unknown", func(t *testing.T) {
+		status, body := getResponse(t, server.URL+"/temperature/12345678?include=forecast")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.JSONEq(t, `{"status_code":400,"message":"invalid include value \"forecast\""}`, body)
+	})
+
+	t.Run("should return address and location when include is location", func(t *testing.T) {
+		zipCode := "12345678"
+		result := model.DetailedTemperature{
+			Temperature: model.Temperature{Celsius: 21.2, Fahrenheit: 70.2, Kelvin: 294.2},
+			Address:     &model.Address{ZipCode: "12345-678", City: "Porto Alegre", State: "RS"},
+			Location:    &model.Location{Name: "Porto Alegre", Region: "Rio Grande do Sul", Country: "Brazil", Latitude: -30.03, Longitude: -51.2, LocalTime: "2025-07-01 14:30"},
+		}
+		mockUsecase.On("GetDetailedTemperatureByZipCode", mock.Anything, model.ZipCode(zipCode), model.TemperatureIncludes{Location: true}).
+			Return(&result, nil).
+			Once()
+
+		status, body := getResponse(t, server.URL+"/temperature/"+zipCode+"?include=location")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{
+			"temp_C":21.2,"temp_F":70.2,"temp_K":294.2,
+			"address":{"zip_code":"12345-678","city":"Porto Alegre","state":"RS"},
+			"location":{"name":"Porto Alegre","region":"Rio Grande do Sul","country":"Brazil","lat":-30.03,"lon":-51.2,"localtime":"2025-07-01 14:30"}
+		}`, body)
+	})
+
+	t.Run("should return air quality when include is air_quality", func(t *testing.T) {
+		zipCode := "12345678"
+		result := model.DetailedTemperature{
+			Temperature: model.Temperature{Celsius: 21.2, Fahrenheit: 70.2, Kelvin: 294.2},
+			AirQuality: &model.AirQualityReport{
+				Location:   model.Location{Name: "Porto Alegre"},
+				AirQuality: model.AirQuality{PM2_5: 8.5, PM10: 12.1, USEPAIndex: 1, USEPACategory: "Good", GBDefraIndex: 1, GBDefraBand: "Low"},
+				Alerts:     []model.WeatherAlert{},
+			},
+		}
+		mockUsecase.On("GetDetailedTemperatureByZipCode", mock.Anything, model.ZipCode(zipCode), model.TemperatureIncludes{AirQuality: true}).
+			Return(&result, nil).
+			Once()
+
+		status, body := getResponse(t, server.URL+"/temperature/"+zipCode+"?include=air_quality")
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{
+			"temp_C":21.2,"temp_F":70.2,"temp_K":294.2,
+			"air_quality":{
+				"location":{"name":"Porto Alegre","lat":0,"lon":0},
+				"air_quality":{"co":0,"no2":0,"o3":0,"so2":0,"pm2_5":8.5,"pm10":12.1,
+					"us_epa_index":1,"us_epa_category":"Good","gb_defra_index":1,"gb_defra_band":"Low"},
+				"alerts":[]
+			}
+		}`, body)
+	})
+
+	t.Run("should render problem+json when the client accepts it", func(t *testing.T) {
+		zipCode := "12345678"
+		mockUsecase.On("GetTemperatureByZipCode", mock.Anything, model.ZipCode(zipCode)).
+			Return(nil, model.NewCodedError(model.ErrorCodeUpstreamTimeout, http.StatusGatewayTimeout, "weather api did not answer in time").
+				WithCause(context.DeadlineExceeded)).
+			Once()
+
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/temperature/"+zipCode, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "application/problem+json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+		assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"type":"about:blank",
+			"title":"Gateway Timeout",
+			"status":504,
+			"detail":"weather api did not answer in time",
+			"instance":"/temperature/12345678",
+			"code":"UPSTREAM_TIMEOUT"
+		}`, string(body))
+	})
+}
+
+func TestGetTemperatureByZipCodeHandler_RequestID(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetTemperatureByZipCodeUsecase(t)
+	h := handler.NewGetTemperatureByZipCodeHandler(mockUsecase)
+	r := chi.NewRouter()
+	r.Use(requestid.Middleware)
+	r.Get("/temperature/{zipCode}", h.Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	do := func(t *testing.T, accept string) (*http.Response, string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/temperature/12", nil)
+		require.NoError(t, err)
+		req.Header.Set(requestid.Header, "req-123")
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, string(body)
+	}
+
+	t.Run("should include the request id in legacy error bodies", func(t *testing.T) {
+		resp, body := do(t, "")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		assert.Equal(t, "req-123", resp.Header.Get(requestid.Header))
+		assert.JSONEq(t, `{"status_code":422,"message":"invalid zipcode","request_id":"req-123"}`, body)
+	})
+
+	t.Run("should include the request id in problem+json bodies", func(t *testing.T) {
+		resp, body := do(t, "application/problem+json")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		assert.Equal(t, "req-123", resp.Header.Get(requestid.Header))
+		assert.JSONEq(t, `{
+			"type":"about:blank",
+			"title":"Unprocessable Entity",
+			"status":422,
+			"detail":"invalid zipcode",
+			"instance":"/temperature/12",
+			"code":"INVALID_ZIPCODE",
+			"request_id":"req-123"
+		}`, body)
+	})
 }
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/business/usecase"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+type GetTemperaturesByZipCodesHandler interface {
+	HttpHandler
+}
+
+type getTemperaturesByZipCodesHandler struct {
+	usecase     usecase.GetTemperaturesByZipCodesUsecase
+	maxZipCodes int
+	response    *responseHandler
+}
+
+type temperaturesResponse struct {
+	Results []model.TemperatureResult `json:"results"`
+}
+
+func NewGetTemperaturesByZipCodesHandler(usecase usecase.GetTemperaturesByZipCodesUsecase, maxZipCodes int) GetTemperaturesByZipCodesHandler {
+	response := NewResponseHandler()
+	return &getTemperaturesByZipCodesHandler{
+		usecase:     usecase,
+		maxZipCodes: maxZipCodes,
+		response:    response,
+	}
+}
+
+// Handle answers 200 when every zip code resolved and 207 Multi-Status when at
+// least one entry carries an error.
+func (h *getTemperaturesByZipCodesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	zipCodes, err := validate.ZipCodes(w, r, h.maxZipCodes)
+	if err != nil {
+		h.response.RequestResponse(w, r, err, err.StatusCode)
+		return
+	}
+
+	results := h.usecase.GetTemperaturesByZipCodes(r.Context(), zipCodes)
+
+	statusCode := http.StatusOK
+	for _, result := range results {
+		if result.Error != nil {
+			statusCode = http.StatusMultiStatus
+			break
+		}
+	}
+	h.response.RequestResponse(w, r, temperaturesResponse{Results: results}, statusCode)
+}
@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	usecaseMock "github.com/Berchon/weather-cloud-run/internal/business/usecase/mock"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/handler"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBatchTestServer(t *testing.T, mockUsecase *usecaseMock.MockGetTemperaturesByZipCodesUsecase) *httptest.Server {
+	h := handler.NewGetTemperaturesByZipCodesHandler(mockUsecase, 3)
+	r := chi.NewRouter()
+	r.Post("/temperatures", h.Handle)
+	server := httptest.NewServer(r)
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+func postResponse(t *testing.T, url, body string) (int, string) {
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(respBody)
+}
+
+func TestGetTemperaturesByZipCodesHandler_HTTP(t *testing.T) {
+	mockUsecase := usecaseMock.NewMockGetTemperaturesByZipCodesUsecase(t)
+	server := setupBatchTestServer(t, mockUsecase)
+
+	t.Run("should return 400 when body is not a list of zip codes", func(t *testing.T) {
+		status, body := postResponse(t, server.URL+"/temperatures", `{"zip_code":"01001000"}`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.JSONEq(t, `{"status_code":400,"message":"request body must be a JSON array of zip codes"}`, body)
+	})
+
+	t.Run("should return 400 when list is empty", func(t *testing.T) {
+		status, body := postResponse(t, server.URL+"/temperatures", `[]`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.JSONEq(t, `{"status_code":400,"message":"zip code list is empty"}`, body)
+	})
+
+	t.Run("should return 400 when list is too long", func(t *testing.T) {
+		status, body := postResponse(t, server.URL+"/temperatures", `["1","2","3","4"]`)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.JSONEq(t, `{"status_code":400,"message":"zip code list has 4 entries, at most 3 are allowed"}`, body)
+	})
+
+	t.Run("should return 200 when every zip code resolves", func(t *testing.T) {
+		results := []model.TemperatureResult{
+			{ZipCode: "01001000", Temperature: &model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293}},
+		}
+		mockUsecase.On("GetTemperaturesByZipCodes", mock.Anything, []string{"01001000"}).Return(results).Once()
+
+		status, body := postResponse(t, server.URL+"/temperatures", `["01001000"]`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, `{"results":[{"zip_code":"01001000","temperature":{"temp_C":20,"temp_F":68,"temp_K":293}}]}`, body)
+	})
+
+	t.Run("should return 207 when some zip codes fail", func(t *testing.T) {
+		results := []model.TemperatureResult{
+			{ZipCode: "01001000", Temperature: &model.Temperature{Celsius: 20, Fahrenheit: 68, Kelvin: 293}},
+			{ZipCode: "abc", Error: model.NewResultError(model.NewInvalidZipCodeError())},
+		}
+		mockUsecase.On("GetTemperaturesByZipCodes", mock.Anything, []string{"01001000", "abc"}).Return(results).Once()
+
+		status, body := postResponse(t, server.URL+"/temperatures", `["01001000","abc"]`)
+		assert.Equal(t, http.StatusMultiStatus, status)
+		assert.JSONEq(t, `{"results":[
+			{"zip_code":"01001000","temperature":{"temp_C":20,"temp_F":68,"temp_K":293}},
+			{"zip_code":"abc","error":{"status_code":422,"code":"INVALID_ZIPCODE","message":"invalid zipcode"}}
+		]}`, body)
+	})
+}
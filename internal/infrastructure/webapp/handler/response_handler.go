@@ -3,16 +3,76 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/requestid"
 )
 
+const problemJSONContentType = "application/problem+json"
+
+// problemDetails is the RFC 7807 rendering of a model.CustomError.
+type problemDetails struct {
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Status    int             `json:"status"`
+	Detail    string          `json:"detail"`
+	Instance  string          `json:"instance,omitempty"`
+	Code      model.ErrorCode `json:"code"`
+	RequestID string          `json:"request_id,omitempty"`
+}
+
+// errorBody is the legacy rendering of a model.CustomError, with the request
+// ID clients can quote when reporting the failure.
+type errorBody struct {
+	*model.CustomError
+	RequestID string `json:"request_id,omitempty"`
+}
+
 type responseHandler struct{}
 
 func NewResponseHandler() *responseHandler {
 	return &responseHandler{}
 }
 
+// RequestResponse writes body as JSON. Errors keep the legacy
+// status_code/message shape unless the client accepts application/problem+json,
+// and both carry the request ID when there is one.
 func (responseHandler *responseHandler) RequestResponse(w http.ResponseWriter, r *http.Request, body interface{}, statusCode int) {
+	if customErr, ok := body.(*model.CustomError); ok {
+		if acceptsProblemJSON(r) {
+			w.Header().Set("Content-Type", problemJSONContentType)
+			w.WriteHeader(statusCode)
+			json.NewEncoder(w).Encode(newProblemDetails(r, customErr, statusCode))
+			return
+		}
+		body = errorBody{CustomError: customErr, RequestID: requestID(r)}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(body)
 }
+
+func acceptsProblemJSON(r *http.Request) bool {
+	return r != nil && strings.Contains(r.Header.Get("Accept"), problemJSONContentType)
+}
+
+func newProblemDetails(r *http.Request, customErr *model.CustomError, statusCode int) problemDetails {
+	return problemDetails{
+		Type:      "about:blank",
+		Title:     http.StatusText(statusCode),
+		Status:    statusCode,
+		Detail:    customErr.Err,
+		Instance:  r.URL.Path,
+		Code:      customErr.Code,
+		RequestID: requestID(r),
+	}
+}
+
+func requestID(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	return requestid.FromContext(r.Context())
+}
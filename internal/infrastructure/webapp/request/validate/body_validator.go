@@ -0,0 +1,32 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+const maxZipCodesBodyBytes = 1 << 20
+
+// ZipCodes decodes a JSON array of zip codes from the request body. The list
+// must not be empty nor hold more than max entries; the zip codes themselves
+// are validated one by one later, so a bad entry does not fail the request.
+func ZipCodes(w http.ResponseWriter, r *http.Request, max int) ([]string, *model.CustomError) {
+	var zipCodes []string
+	body := http.MaxBytesReader(w, r.Body, maxZipCodesBodyBytes)
+	if err := json.NewDecoder(body).Decode(&zipCodes); err != nil {
+		return nil, model.NewCustomError(http.StatusBadRequest,
+			"request body must be a JSON array of zip codes").WithCause(err)
+	}
+
+	if len(zipCodes) == 0 {
+		return nil, model.NewCustomError(http.StatusBadRequest, "zip code list is empty")
+	}
+	if len(zipCodes) > max {
+		return nil, model.NewCustomError(http.StatusBadRequest,
+			fmt.Sprintf("zip code list has %d entries, at most %d are allowed", len(zipCodes), max))
+	}
+	return zipCodes, nil
+}
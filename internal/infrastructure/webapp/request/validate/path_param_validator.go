@@ -2,13 +2,17 @@ package validate
 
 import (
 	"net/http"
+	"net/url"
 
 	"github.com/Berchon/weather-cloud-run/internal/business/model"
 	"github.com/go-chi/chi/v5"
 )
 
 const (
-	zipCodePathParam = "zipCode"
+	zipCodePathParam  = "zipCode"
+	statePathParam    = "uf"
+	cityPathParam     = "city"
+	ibgeCodePathParam = "ibgeCode"
 )
 
 func ZipCode(r *http.Request) (*model.ZipCode, *model.CustomError) {
@@ -19,3 +23,21 @@ func ZipCode(r *http.Request) (*model.ZipCode, *model.CustomError) {
 	zipCode, err := model.BuildZipCode(zipCodeParam)
 	return zipCode, err
 }
+
+// City reads /{uf}/{city}; the city name may be URL encoded ("S%C3%A3o%20Paulo").
+func City(r *http.Request) (*model.City, *model.CustomError) {
+	state := chi.URLParam(r, statePathParam)
+	name, err := url.PathUnescape(chi.URLParam(r, cityPathParam))
+	if err != nil {
+		return nil, model.NewCodedError(model.ErrorCodeInvalidCity, http.StatusUnprocessableEntity, "invalid city")
+	}
+	return model.BuildCity(name, state)
+}
+
+func IBGECode(r *http.Request) (*model.IBGECode, *model.CustomError) {
+	ibgeCodeParam := chi.URLParam(r, ibgeCodePathParam)
+	if len(ibgeCodeParam) == 0 {
+		return nil, model.NewCustomError(http.StatusBadRequest, "ibge code path param is not present")
+	}
+	return model.BuildIBGECode(ibgeCodeParam)
+}
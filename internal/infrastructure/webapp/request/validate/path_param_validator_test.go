@@ -57,3 +57,56 @@ func TestZipCode(t *testing.T) {
 		assert.Equal(t, expected, zipCode)
 	})
 }
+
+func TestCity(t *testing.T) {
+	t.Run("Should return a city When the name is URL encoded", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/temperature/city/sp/S%C3%A3o%20Paulo", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uf", "sp")
+		rctx.URLParams.Add("city", "S%C3%A3o%20Paulo")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		city, err := validate.City(r)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &model.City{Name: "São Paulo", State: "SP"}, city)
+	})
+
+	t.Run("Should return an error When the UF is unknown", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/temperature/city/XX/Recife", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("uf", "XX")
+		rctx.URLParams.Add("city", "Recife")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		city, err := validate.City(r)
+
+		assert.Nil(t, city)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	})
+}
+
+func TestIBGECode(t *testing.T) {
+	t.Run("Should return an error When ibge code is not present", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/temperature/ibge/", nil)
+		rctx := chi.NewRouteContext()
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		ibgeCode, err := validate.IBGECode(r)
+
+		assert.Nil(t, ibgeCode)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("Should return an ibge code When it is valid", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/temperature/ibge/4314902", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("ibgeCode", "4314902")
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+		ibgeCode, err := validate.IBGECode(r)
+
+		assert.Nil(t, err)
+		assert.Equal(t, model.IBGECode("4314902"), *ibgeCode)
+	})
+}
@@ -0,0 +1,105 @@
+package validate
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+)
+
+const (
+	includeQueryParam   = "include"
+	includeLocation     = "location"
+	includeAirQuality   = "air_quality"
+	latitudeQueryParam  = "lat"
+	longitudeQueryParam = "lon"
+	daysQueryParam      = "days"
+	dateQueryParam      = "date"
+)
+
+// historyLocation is where /history takes "today". time/tzdata embeds the
+// zone, as the scratch image ships no zoneinfo.
+var historyLocation = mustLoadLocation("America/Sao_Paulo")
+
+// now is replaced in tests.
+var now = time.Now
+
+// TemperatureIncludes reads the optional blocks asked for through
+// ?include=, a comma separated list of location and air_quality. Unknown
+// include values are rejected.
+func TemperatureIncludes(r *http.Request) (model.TemperatureIncludes, *model.CustomError) {
+	var includes model.TemperatureIncludes
+	include := r.URL.Query().Get(includeQueryParam)
+	if include == "" {
+		return includes, nil
+	}
+
+	for _, value := range strings.Split(include, ",") {
+		switch strings.TrimSpace(value) {
+		case includeLocation:
+			includes.Location = true
+		case includeAirQuality:
+			includes.AirQuality = true
+		default:
+			return model.TemperatureIncludes{}, model.NewCustomError(http.StatusBadRequest,
+				fmt.Sprintf("invalid include value %q", strings.TrimSpace(value)))
+		}
+	}
+	return includes, nil
+}
+
+// Coordinates reads ?lat=&lon= in decimal degrees. Missing values are a bad
+// request; values that are not numbers or out of range are unprocessable.
+func Coordinates(r *http.Request) (*model.Coordinates, *model.CustomError) {
+	query := r.URL.Query()
+	latitudeParam, longitudeParam := query.Get(latitudeQueryParam), query.Get(longitudeQueryParam)
+	if latitudeParam == "" || longitudeParam == "" {
+		return nil, model.NewCustomError(http.StatusBadRequest, "lat and lon query params are required")
+	}
+
+	latitude, latErr := strconv.ParseFloat(latitudeParam, 64)
+	longitude, lonErr := strconv.ParseFloat(longitudeParam, 64)
+	if latErr != nil || lonErr != nil {
+		return nil, model.NewCodedError(model.ErrorCodeInvalidCoordinates, http.StatusUnprocessableEntity, "invalid coordinates")
+	}
+	return model.BuildCoordinates(latitude, longitude)
+}
+
+// ForecastDays reads ?days=, defaulting to a single day, and requires it to be
+// between 1 and max.
+func ForecastDays(r *http.Request, max int) (int, *model.CustomError) {
+	daysParam := r.URL.Query().Get(daysQueryParam)
+	if daysParam == "" {
+		return 1, nil
+	}
+
+	days, err := strconv.Atoi(daysParam)
+	if err != nil || days < 1 || days > max {
+		return 0, model.NewCustomError(http.StatusBadRequest,
+			fmt.Sprintf("days must be a number between 1 and %d", max))
+	}
+	return days, nil
+}
+
+// HistoryDate reads ?date=YYYY-MM-DD, which must be at most maxDays before
+// today. Today is taken in Brazil: UTC runs three hours ahead, so from 21:00
+// BRT it would already accept Brazil's tomorrow.
+func HistoryDate(r *http.Request, maxDays int) (*time.Time, *model.CustomError) {
+	dateParam := r.URL.Query().Get(dateQueryParam)
+	if dateParam == "" {
+		return nil, model.NewCustomError(http.StatusBadRequest, "date query param is required")
+	}
+	return model.BuildHistoryDate(dateParam, now().In(historyLocation), maxDays)
+}
+
+func mustLoadLocation(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
@@ -0,0 +1,33 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryDate_BrazilianToday(t *testing.T) {
+	// 22:30 BRT on July 10 is already July 11 in UTC.
+	now = func() time.Time { return time.Date(2025, 7, 11, 1, 30, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = time.Now })
+
+	t.Run("Should reject Brazil's tomorrow When UTC has already reached it", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678/history?date=2025-07-11", nil)
+		date, err := HistoryDate(req, 7)
+		assert.Nil(t, date)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+			assert.Equal(t, "date 2025-07-11 is in the future", err.Error())
+		}
+	})
+
+	t.Run("Should accept Brazil's today", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678/history?date=2025-07-10", nil)
+		date, err := HistoryDate(req, 7)
+		assert.Nil(t, err)
+		assert.Equal(t, "2025-07-10", date.Format("2006-01-02"))
+	})
+}
@@ -0,0 +1,123 @@
+package validate_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Berchon/weather-cloud-run/internal/business/model"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/request/validate"
+)
+
+func TestTemperatureIncludes(t *testing.T) {
+	t.Run("Should include nothing When include is absent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678", nil)
+		includes, err := validate.TemperatureIncludes(req)
+		assert.Nil(t, err)
+		assert.False(t, includes.Any())
+	})
+
+	t.Run("Should include location When include is location", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678?include=location", nil)
+		includes, err := validate.TemperatureIncludes(req)
+		assert.Nil(t, err)
+		assert.Equal(t, model.TemperatureIncludes{Location: true}, includes)
+	})
+
+	t.Run("Should include both blocks When include lists location and air_quality", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678?include=air_quality,%20location", nil)
+		includes, err := validate.TemperatureIncludes(req)
+		assert.Nil(t, err)
+		assert.Equal(t, model.TemperatureIncludes{Location: true, AirQuality: true}, includes)
+	})
+
+	t.Run("Should return an error When include value is unknown", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678?include=location,forecast", nil)
+		includes, err := validate.TemperatureIncludes(req)
+		assert.False(t, includes.Any())
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+			assert.Equal(t, `invalid include value "forecast"`, err.Error())
+		}
+	})
+}
+
+func TestCoordinates(t *testing.T) {
+	t.Run("Should return an error When lat or lon is absent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/coordinates?lat=-30.03", nil)
+		coordinates, err := validate.Coordinates(req)
+		assert.Nil(t, coordinates)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("Should return an error When lat is not a number", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/coordinates?lat=south&lon=-51.2", nil)
+		coordinates, err := validate.Coordinates(req)
+		assert.Nil(t, coordinates)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+		assert.Equal(t, "invalid coordinates", err.Error())
+	})
+
+	t.Run("Should return coordinates When lat and lon are valid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/coordinates?lat=-30.03&lon=-51.2", nil)
+		coordinates, err := validate.Coordinates(req)
+		assert.Nil(t, err)
+		assert.Equal(t, -30.03, coordinates.Latitude)
+		assert.Equal(t, -51.2, coordinates.Longitude)
+	})
+}
+
+func TestForecastDays(t *testing.T) {
+	t.Run("Should return one day When days is absent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/forecast/12345678", nil)
+		days, err := validate.ForecastDays(req, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, days)
+	})
+
+	t.Run("Should return days When it is within bounds", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/forecast/12345678?days=3", nil)
+		days, err := validate.ForecastDays(req, 3)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, days)
+	})
+
+	t.Run("Should return an error When days is out of bounds or not a number", func(t *testing.T) {
+		for _, value := range []string{"0", "4", "-1", "two"} {
+			req := httptest.NewRequest(http.MethodGet, "/forecast/12345678?days="+value, nil)
+			_, err := validate.ForecastDays(req, 3)
+			if assert.NotNil(t, err) {
+				assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+				assert.Equal(t, "days must be a number between 1 and 3", err.Error())
+			}
+		}
+	})
+}
+
+func TestHistoryDate(t *testing.T) {
+	t.Run("Should return an error When date is absent", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678/history", nil)
+		date, err := validate.HistoryDate(req, 7)
+		assert.Nil(t, date)
+		assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	})
+
+	t.Run("Should return an error When date is in the future", func(t *testing.T) {
+		tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678/history?date="+tomorrow, nil)
+		date, err := validate.HistoryDate(req, 7)
+		assert.Nil(t, date)
+		assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	})
+
+	t.Run("Should return the date When it is within range", func(t *testing.T) {
+		yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+		req := httptest.NewRequest(http.MethodGet, "/temperature/12345678/history?date="+yesterday, nil)
+		date, err := validate.HistoryDate(req, 7)
+		assert.Nil(t, err)
+		assert.Equal(t, yesterday, date.Format("2006-01-02"))
+	})
+}
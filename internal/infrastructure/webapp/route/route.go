@@ -1,24 +1,38 @@
 package route
 
 import (
-	"github.com/Berchon/weather-cloud-run/internal/infrastructure/configs"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/deadline"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/dependencies"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/logging"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/requestid"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/tracing"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
 func ConfigureApplicationRoutes(handlers *dependencies.Handlers) *chi.Mux {
-	port := configs.GetWebServerPort()
 	router := chi.NewRouter()
-	registerRoutes(port, router, handlers)
+	registerRoutes(router, handlers)
 	return router
 }
 
-func registerRoutes(port string, router *chi.Mux, handlers *dependencies.Handlers) {
-	router.Use(middleware.Logger)
+func registerRoutes(router *chi.Mux, handlers *dependencies.Handlers) {
+	router.Use(requestid.Middleware)
+	router.Use(tracing.Middleware(handlers.TracerProvider))
+	router.Use(handlers.HTTPMetrics.Middleware)
+	router.Use(logging.Middleware(handlers.Logger))
 	router.Use(middleware.Recoverer)
+	router.Use(deadline.Middleware(handlers.RequestTimeout))
 
 	router.Get("/status", handlers.GetStatusHandler.Handle)
-	// router.Post("/reload", getAddressByCep.Handle)
+	router.Get("/metrics", handlers.GetMetricsHandler.Handle)
 	router.Get("/temperature/{zipCode}", handlers.GetTemperatureByZipCodeHandler.Handle)
+	router.Get("/temperature/{zipCode}/history", handlers.GetHistoryByZipCodeHandler.Handle)
+	router.Get("/temperature/city/{uf}/{city}", handlers.GetTemperatureByCityHandler.Handle)
+	router.Get("/temperature/coordinates", handlers.GetTemperatureByCoordinatesHandler.Handle)
+	router.Get("/temperature/ibge/{ibgeCode}", handlers.GetTemperatureByIBGECodeHandler.Handle)
+	router.Get("/forecast/{zipCode}", handlers.GetForecastByZipCodeHandler.Handle)
+	router.Get("/conditions/{zipCode}", handlers.GetConditionsByZipCodeHandler.Handle)
+	router.Get("/air-quality/{zipCode}", handlers.GetAirQualityByZipCodeHandler.Handle)
+	router.Post("/temperatures", handlers.GetTemperaturesByZipCodesHandler.Handle)
 }
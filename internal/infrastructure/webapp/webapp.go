@@ -1,37 +1,242 @@
 package webapp
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/configs"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/dependencies"
+	"github.com/Berchon/weather-cloud-run/internal/infrastructure/logging"
 	"github.com/Berchon/weather-cloud-run/internal/infrastructure/webapp/route"
+	"github.com/go-chi/chi/v5"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type WebApp interface {
-	Start()
+	Start() error
+	Stop(ctx context.Context) error
+	Reload() error
 }
 
-type webApp struct{}
+type webApp struct {
+	mu             sync.Mutex
+	server         *http.Server
+	store          *configs.Store
+	tracerProvider *sdktrace.TracerProvider
+	logger         *slog.Logger
+	stopped        chan struct{}
+
+	// router serves each request with the components built from the
+	// configuration current when it arrived; Reload swaps it whole.
+	router atomic.Pointer[chi.Mux]
+}
 
 func New() WebApp {
-	return &webApp{}
+	return &webApp{
+		logger:  slog.Default(),
+		stopped: make(chan struct{}),
+	}
+}
+
+// Start loads the configuration, serves HTTP requests and blocks until the
+// server stops. On SIGHUP the configuration is reloaded, see Reload. On
+// SIGINT/SIGTERM in-flight requests are drained within the configured shutdown
+// timeout. A nil error means the server was shut down gracefully, either by a
+// signal or by a call to Stop.
+func (webApp *webApp) Start() error {
+	store, warnings, err := configs.NewStore(".")
+	if err != nil {
+		return fmt.Errorf("error loading configs: %w", err)
+	}
+	cfg := store.Current()
+
+	logger, err := newLogger(cfg)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	for _, warning := range warnings {
+		logger.Warn("loading configs", "error", warning.Error())
+	}
+
+	dependencies := dependencies.BuildDependencies(cfg, logger)
+	webApp.router.Store(route.ConfigureApplicationRoutes(dependencies))
+
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%s", cfg.WebServerPort),
+		Handler:      http.HandlerFunc(webApp.serveHTTP),
+		ReadTimeout:  cfg.ServerReadTimeout,
+		WriteTimeout: cfg.ServerWriteTimeout,
+		IdleTimeout:  cfg.ServerIdleTimeout,
+	}
+
+	webApp.mu.Lock()
+	webApp.server = server
+	webApp.store = store
+	webApp.tracerProvider = dependencies.TracerProvider
+	webApp.logger = logger
+	webApp.mu.Unlock()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("starting server", "addr", server.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	for {
+		select {
+		case err := <-serverErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("error starting server: %w", err)
+			}
+			// Stop was called: wait for it to finish draining before returning.
+			<-webApp.stopped
+			return nil
+		case <-reload:
+			// Reload logs its own failures and keeps the current configuration.
+			_ = webApp.Reload()
+		case <-ctx.Done():
+			webApp.currentLogger().Info("shutdown signal received, draining in-flight requests")
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), store.Current().ServerShutdownTimeout)
+			defer cancel()
+			return webApp.Stop(shutdownCtx)
+		}
+	}
+}
+
+// Reload loads the configuration again and serves the next requests with
+// components built from it; requests in flight finish on the previous ones.
+// An invalid configuration is logged and the current one kept. Caches, circuit
+// breakers and metrics start afresh. The port and the server timeouts are only
+// read by Start: changing them takes a restart.
+func (webApp *webApp) Reload() error {
+	webApp.mu.Lock()
+	store := webApp.store
+	logger := webApp.logger
+	webApp.mu.Unlock()
+
+	if store == nil {
+		return errors.New("error reloading configs: server not started")
+	}
+
+	previous := store.Current()
+	cfg, warnings, err := store.Reload()
+	if err != nil {
+		logger.Error("reloading configs failed, keeping the current ones", "error", err)
+		return fmt.Errorf("error reloading configs: %w", err)
+	}
+
+	if next, err := newLogger(cfg); err != nil {
+		logger.Error("reloading logger failed, keeping the current one", "error", err)
+	} else {
+		logger = next
+		slog.SetDefault(logger)
+	}
+	for _, warning := range warnings {
+		logger.Warn("reloading configs", "error", warning.Error())
+	}
+	if cfg.WebServerPort != previous.WebServerPort || cfg.ServerReadTimeout != previous.ServerReadTimeout ||
+		cfg.ServerWriteTimeout != previous.ServerWriteTimeout || cfg.ServerIdleTimeout != previous.ServerIdleTimeout {
+		logger.Warn("port and server timeouts are only applied on restart")
+	}
+
+	dependencies := dependencies.BuildDependencies(cfg, logger)
+	webApp.router.Store(route.ConfigureApplicationRoutes(dependencies))
+
+	webApp.mu.Lock()
+	previousTracerProvider := webApp.tracerProvider
+	webApp.tracerProvider = dependencies.TracerProvider
+	webApp.logger = logger
+	webApp.mu.Unlock()
+
+	// Requests still on the previous components end within their timeout;
+	// their spans are flushed once they are done.
+	if previousTracerProvider != nil {
+		time.AfterFunc(previous.RequestTimeout, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), previous.ServerShutdownTimeout)
+			defer cancel()
+			if err := previousTracerProvider.Shutdown(ctx); err != nil {
+				logger.Error("flushing traces failed", "error", err)
+			}
+		})
+	}
+
+	logger.Info("configs reloaded")
+	return nil
+}
+
+func (webApp *webApp) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	webApp.router.Load().ServeHTTP(w, r)
+}
+
+func (webApp *webApp) currentLogger() *slog.Logger {
+	webApp.mu.Lock()
+	defer webApp.mu.Unlock()
+	return webApp.logger
 }
 
-func (webApp *webApp) Start() {
-	err := configs.LoadConfig(".")
+func newLogger(cfg configs.Config) (*slog.Logger, error) {
+	logger, err := logging.New(os.Stdout, logging.Settings{
+		Level:        cfg.LogLevel,
+		Format:       cfg.LogFormat,
+		CloudLogging: cfg.LogCloudLogging,
+	})
 	if err != nil {
-		log.Fatal("Error loading configs:", err)
-		panic(err)
+		return nil, fmt.Errorf("error configuring logger: %w", err)
+	}
+	return logger, nil
+}
+
+// Stop gracefully shuts the server down, waiting for in-flight requests until
+// ctx is done, then flushes the spans not exported yet. It is safe to call
+// before Start and more than once.
+func (webApp *webApp) Stop(ctx context.Context) error {
+	webApp.mu.Lock()
+	server := webApp.server
+	tracerProvider := webApp.tracerProvider
+	logger := webApp.logger
+	webApp.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	defer webApp.markStopped()
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("error shutting down server: %w", err)
+	}
+	if tracerProvider != nil {
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			logger.Error("flushing traces failed", "error", err)
+		}
 	}
 
-	dependencies := dependencies.BuildDependencies()
+	logger.Info("server stopped")
+	return nil
+}
 
-	router := route.ConfigureApplicationRoutes(dependencies)
+func (webApp *webApp) markStopped() {
+	webApp.mu.Lock()
+	defer webApp.mu.Unlock()
 
-	port := fmt.Sprintf(":%s", configs.GetWebServerPort())
-	log.Printf("Starting server on port %s\n", port)
-	http.ListenAndServe(port, router)
+	select {
+	case <-webApp.stopped:
+	default:
+		close(webApp.stopped)
+	}
 }
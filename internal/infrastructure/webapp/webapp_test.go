@@ -0,0 +1,146 @@
+package webapp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func freePort(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+}
+
+func waitForServer(t *testing.T, url string) {
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestWebApp_StartAndStop(t *testing.T) {
+	t.Setenv("WEATHER_API_KEY", "test-key")
+
+	t.Run("should serve requests and return nil when stopped", func(t *testing.T) {
+		port := freePort(t)
+		t.Setenv("WEB_SERVER_PORT", port)
+
+		app := New()
+		startErr := make(chan error, 1)
+		go func() { startErr <- app.Start() }()
+
+		waitForServer(t, fmt.Sprintf("http://127.0.0.1:%s/status", port))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.NoError(t, app.Stop(ctx))
+
+		select {
+		case err := <-startErr:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Start did not return after Stop")
+		}
+	})
+
+	t.Run("should return error when port is already in use", func(t *testing.T) {
+		listener, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+		defer listener.Close()
+		t.Setenv("WEB_SERVER_PORT", strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+
+		err = New().Start()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error starting server")
+	})
+
+	t.Run("should return error when the config is invalid", func(t *testing.T) {
+		t.Setenv("BATCH_WORKERS", "0")
+
+		err := New().Start()
+		assert.ErrorContains(t, err, "BATCH_WORKERS must be at least 1")
+	})
+
+	t.Run("should return error when the WeatherAPI key is missing", func(t *testing.T) {
+		t.Setenv("WEATHER_API_KEY", "")
+
+		err := New().Start()
+		assert.ErrorContains(t, err, "WEATHER_API_KEY must be set")
+	})
+
+	t.Run("should return nil when Stop is called before Start", func(t *testing.T) {
+		assert.NoError(t, New().Stop(context.Background()))
+	})
+}
+
+func postZipCodes(t *testing.T, url string) int {
+	resp, err := http.Post(url, "application/json", strings.NewReader(`["abc","def"]`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestWebApp_Reload(t *testing.T) {
+	t.Setenv("WEATHER_API_KEY", "test-key")
+
+	t.Run("should serve the next requests with the reloaded configs", func(t *testing.T) {
+		port := freePort(t)
+		t.Setenv("WEB_SERVER_PORT", port)
+		t.Setenv("BATCH_MAX_ZIP_CODES", "1")
+
+		app := New()
+		go func() { _ = app.Start() }()
+		defer app.Stop(context.Background())
+		waitForServer(t, fmt.Sprintf("http://127.0.0.1:%s/status", port))
+		url := fmt.Sprintf("http://127.0.0.1:%s/temperatures", port)
+
+		assert.Equal(t, http.StatusBadRequest, postZipCodes(t, url))
+
+		t.Setenv("BATCH_MAX_ZIP_CODES", "5")
+		require.NoError(t, app.Reload())
+		assert.Equal(t, http.StatusMultiStatus, postZipCodes(t, url))
+
+		t.Setenv("BATCH_WORKERS", "0")
+		assert.ErrorContains(t, app.Reload(), "BATCH_WORKERS must be at least 1")
+		assert.Equal(t, http.StatusMultiStatus, postZipCodes(t, url))
+	})
+
+	t.Run("should reload on SIGHUP", func(t *testing.T) {
+		port := freePort(t)
+		t.Setenv("WEB_SERVER_PORT", port)
+		t.Setenv("BATCH_MAX_ZIP_CODES", "1")
+
+		app := New()
+		go func() { _ = app.Start() }()
+		defer app.Stop(context.Background())
+		waitForServer(t, fmt.Sprintf("http://127.0.0.1:%s/status", port))
+		url := fmt.Sprintf("http://127.0.0.1:%s/temperatures", port)
+
+		t.Setenv("BATCH_MAX_ZIP_CODES", "5")
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+
+		assert.Eventually(t, func() bool {
+			return postZipCodes(t, url) == http.StatusMultiStatus
+		}, 5*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("should return error when called before Start", func(t *testing.T) {
+		assert.ErrorContains(t, New().Reload(), "server not started")
+	})
+}